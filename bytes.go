@@ -1,8 +1,14 @@
 package stringutils
 
+import "encoding/binary"
+
 // ToLowerBytes converts ascii slice to lower-case in-place.
 func ToLowerBytes(b []byte) []byte {
-	for i := 0; i < len(b); i++ {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		binary.LittleEndian.PutUint64(b[i:], swarToLower(binary.LittleEndian.Uint64(b[i:])))
+	}
+	for ; i < len(b); i++ {
 		b[i] = toLowerTable[b[i]]
 	}
 	return b
@@ -10,7 +16,11 @@ func ToLowerBytes(b []byte) []byte {
 
 // ToUpperBytes converts ascii slice to upper-case in-place.
 func ToUpperBytes(b []byte) []byte {
-	for i := 0; i < len(b); i++ {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		binary.LittleEndian.PutUint64(b[i:], swarToUpper(binary.LittleEndian.Uint64(b[i:])))
+	}
+	for ; i < len(b); i++ {
 		b[i] = toUpperTable[b[i]]
 	}
 	return b
@@ -56,7 +66,13 @@ func EqualFoldBytes(b, s []byte) bool {
 	if len(b) != len(s) {
 		return false
 	}
-	for i := len(b) - 1; i >= 0; i-- {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		if x, y := binary.LittleEndian.Uint64(b[i:]), binary.LittleEndian.Uint64(s[i:]); x != y && swarToLower(x) != swarToLower(y) {
+			return false
+		}
+	}
+	for ; i < len(b); i++ {
 		if toUpperTable[b[i]] != toUpperTable[s[i]] {
 			return false
 		}
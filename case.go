@@ -138,10 +138,7 @@ const (
 // ToLower converts ascii string to lower-case
 func ToLower(b string) string {
 	res := make([]byte, len(b))
-	copy(res, b)
-	for i := 0; i < len(res); i++ {
-		res[i] = toLowerTable[res[i]]
-	}
+	toLowerString(res, b)
 
 	return UnsafeString(res)
 }
@@ -149,10 +146,7 @@ func ToLower(b string) string {
 // ToUpper converts ascii string to upper-case
 func ToUpper(b string) string {
 	res := make([]byte, len(b))
-	copy(res, b)
-	for i := 0; i < len(res); i++ {
-		res[i] = toUpperTable[res[i]]
-	}
+	toUpperString(res, b)
 
 	return UnsafeString(res)
 }
@@ -162,10 +156,32 @@ func EqualFold(b, s string) bool {
 	if len(b) != len(s) {
 		return false
 	}
-	for i := len(b) - 1; i >= 0; i-- {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		if x, y := load64(b, i), load64(s, i); x != y && swarToLower(x) != swarToLower(y) {
+			return false
+		}
+	}
+	for ; i < len(b); i++ {
 		if toUpperTable[b[i]] != toUpperTable[s[i]] {
 			return false
 		}
 	}
 	return true
 }
+
+// IsASCII reports whether s contains only ASCII characters
+func IsASCII(s string) bool {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if load64(s, i)&swarHi != 0 {
+			return false
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+package stringutils
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// identifier case conversion modes
+const (
+	identLower = iota // snake_case, kebab-case
+	identUpper        // SCREAMING_SNAKE
+	identTitle        // PascalCase
+	identCamel        // camelCase
+)
+
+// identWriter writes converted identifier to Builder.
+// If sb is nil, it compares output with src and allocate output buffer only on first difference,
+// so unchanged source (or it's prefix) is returned without allocation.
+type identWriter struct {
+	sb      *Builder
+	src     string
+	pos     int    // length of output, equal to src prefix (while not changed)
+	out     []byte // output buffer, allocated on first difference with src
+	changed bool
+}
+
+func (w *identWriter) writeByte(c byte) {
+	if w.sb != nil {
+		_ = w.sb.WriteByte(c)
+		return
+	}
+	if !w.changed {
+		if w.pos < len(w.src) && w.src[w.pos] == c {
+			w.pos++
+			return
+		}
+		w.changed = true
+		w.out = make([]byte, w.pos, len(w.src)+len(w.src)/2+utf8.UTFMax)
+		copy(w.out, w.src)
+	}
+	w.out = append(w.out, c)
+}
+
+func (w *identWriter) writeRune(r rune) {
+	if r < utf8.RuneSelf {
+		w.writeByte(byte(r))
+		return
+	}
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	for i := 0; i < n; i++ {
+		w.writeByte(buf[i])
+	}
+}
+
+func (w *identWriter) string() string {
+	if w.changed {
+		return UnsafeString(w.out)
+	}
+	return w.src[:w.pos]
+}
+
+// decodeIdentRune decode rune at s[i] (with ASCII fast path).
+func decodeIdentRune(s string, i int) (rune, int) {
+	if c := s[i]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(s[i:])
+}
+
+// ASCII character classes for identifier case conversion
+const (
+	identClassLower = 1 << iota
+	identClassUpper
+	identClassDigit
+)
+
+var identClass = func() (t [utf8.RuneSelf]uint8) {
+	for c := 'a'; c <= 'z'; c++ {
+		t[c] = identClassLower
+	}
+	for c := 'A'; c <= 'Z'; c++ {
+		t[c] = identClassUpper
+	}
+	for c := '0'; c <= '9'; c++ {
+		t[c] = identClassDigit
+	}
+	return
+}()
+
+func isIdentUpper(r rune) bool {
+	if r < utf8.RuneSelf {
+		return identClass[r] == identClassUpper
+	}
+	return unicode.IsUpper(r)
+}
+
+func isIdentLower(r rune) bool {
+	if r < utf8.RuneSelf {
+		return identClass[r] == identClassLower
+	}
+	return unicode.IsLower(r)
+}
+
+func isIdentLetterOrDigit(r rune) bool {
+	if r < utf8.RuneSelf {
+		return identClass[r] != 0
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// writeIdentWord writes word, converted to upper or lower case (or title case, if title).
+func (w *identWriter) writeIdentWord(word string, upper, title bool) {
+	for i := 0; i < len(word); {
+		c := word[i]
+		if c < utf8.RuneSelf {
+			if upper || title {
+				c = toUpperTable[c]
+			} else {
+				c = toLowerTable[c]
+			}
+			w.writeByte(c)
+			i++
+		} else {
+			r, size := utf8.DecodeRuneInString(word[i:])
+			switch {
+			case title:
+				r = unicode.ToTitle(r)
+			case upper:
+				r = unicode.ToUpper(r)
+			default:
+				r = unicode.ToLower(r)
+			}
+			w.writeRune(r)
+			i += size
+		}
+		title = false
+	}
+}
+
+// writeIdent splits s into words and writes them, converted by mode and joined with sep (if not 0).
+//
+// Words are separated by any non-letter and non-digit characters (dropped),
+// by lower to upper case transition (fooBar -> foo, Bar) and before upper case letter, followed by lower case
+// (HTTPServer -> HTTP, Server; Int32Value -> Int32, Value).
+func (w *identWriter) writeIdent(s string, mode int, sep byte) {
+	words := 0
+	for i := 0; i < len(s); {
+		r, size := decodeIdentRune(s, i)
+		if !isIdentLetterOrDigit(r) {
+			i += size
+			continue
+		}
+		start := i
+		prev := r
+		i += size
+		for i < len(s) {
+			r, size = decodeIdentRune(s, i)
+			if !isIdentLetterOrDigit(r) {
+				break
+			}
+			if isIdentUpper(r) {
+				if isIdentLower(prev) {
+					break
+				}
+				if i+size < len(s) {
+					if next, _ := decodeIdentRune(s, i+size); isIdentLower(next) {
+						break
+					}
+				}
+			}
+			prev = r
+			i += size
+		}
+
+		if words > 0 && sep != 0 {
+			w.writeByte(sep)
+		}
+		switch mode {
+		case identUpper:
+			w.writeIdentWord(s[start:i], true, false)
+		case identTitle:
+			w.writeIdentWord(s[start:i], false, true)
+		case identCamel:
+			w.writeIdentWord(s[start:i], false, words > 0)
+		default:
+			w.writeIdentWord(s[start:i], false, false)
+		}
+		words++
+	}
+}
+
+// WriteSnakeCase appends s, converted to snake_case (HTTPServerID -> http_server_id).
+func (sb *Builder) WriteSnakeCase(s string) {
+	w := identWriter{sb: sb}
+	w.writeIdent(s, identLower, '_')
+}
+
+// WriteKebabCase appends s, converted to kebab-case (HTTPServerID -> http-server-id).
+func (sb *Builder) WriteKebabCase(s string) {
+	w := identWriter{sb: sb}
+	w.writeIdent(s, identLower, '-')
+}
+
+// WriteScreamingSnake appends s, converted to SCREAMING_SNAKE_CASE (HTTPServerID -> HTTP_SERVER_ID).
+func (sb *Builder) WriteScreamingSnake(s string) {
+	w := identWriter{sb: sb}
+	w.writeIdent(s, identUpper, '_')
+}
+
+// WriteCamelCase appends s, converted to camelCase (http_server_id -> httpServerId).
+func (sb *Builder) WriteCamelCase(s string) {
+	w := identWriter{sb: sb}
+	w.writeIdent(s, identCamel, 0)
+}
+
+// WritePascalCase appends s, converted to PascalCase (http_server_id -> HttpServerId).
+func (sb *Builder) WritePascalCase(s string) {
+	w := identWriter{sb: sb}
+	w.writeIdent(s, identTitle, 0)
+}
+
+// ToSnakeCase returns s, converted to snake_case (HTTPServerID -> http_server_id).
+// If s is already in snake_case, it's returned without allocation.
+func ToSnakeCase(s string) string {
+	w := identWriter{src: s}
+	w.writeIdent(s, identLower, '_')
+	return w.string()
+}
+
+// ToKebabCase returns s, converted to kebab-case (HTTPServerID -> http-server-id).
+// If s is already in kebab-case, it's returned without allocation.
+func ToKebabCase(s string) string {
+	w := identWriter{src: s}
+	w.writeIdent(s, identLower, '-')
+	return w.string()
+}
+
+// ToScreamingSnake returns s, converted to SCREAMING_SNAKE_CASE (HTTPServerID -> HTTP_SERVER_ID).
+// If s is already in SCREAMING_SNAKE_CASE, it's returned without allocation.
+func ToScreamingSnake(s string) string {
+	w := identWriter{src: s}
+	w.writeIdent(s, identUpper, '_')
+	return w.string()
+}
+
+// ToCamelCase returns s, converted to camelCase (http_server_id -> httpServerId).
+// If s is already in camelCase, it's returned without allocation.
+func ToCamelCase(s string) string {
+	w := identWriter{src: s}
+	w.writeIdent(s, identCamel, 0)
+	return w.string()
+}
+
+// ToPascalCase returns s, converted to PascalCase (http_server_id -> HttpServerId).
+// If s is already in PascalCase, it's returned without allocation.
+func ToPascalCase(s string) string {
+	w := identWriter{src: s}
+	w.writeIdent(s, identTitle, 0)
+	return w.string()
+}
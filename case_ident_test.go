@@ -0,0 +1,114 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var identCaseTests = []struct {
+	s         string
+	snake     string
+	kebab     string
+	screaming string
+	camel     string
+	pascal    string
+}{
+	{"", "", "", "", "", ""},
+	{"foo", "foo", "foo", "FOO", "foo", "Foo"},
+	{"fooBar", "foo_bar", "foo-bar", "FOO_BAR", "fooBar", "FooBar"},
+	{"FooBar", "foo_bar", "foo-bar", "FOO_BAR", "fooBar", "FooBar"},
+	{"foo_bar", "foo_bar", "foo-bar", "FOO_BAR", "fooBar", "FooBar"},
+	{"foo-bar", "foo_bar", "foo-bar", "FOO_BAR", "fooBar", "FooBar"},
+	{"FOO_BAR", "foo_bar", "foo-bar", "FOO_BAR", "fooBar", "FooBar"},
+	{"  foo  bar.baz__", "foo_bar_baz", "foo-bar-baz", "FOO_BAR_BAZ", "fooBarBaz", "FooBarBaz"},
+	{"HTTPServerID", "http_server_id", "http-server-id", "HTTP_SERVER_ID", "httpServerId", "HttpServerId"},
+	{"ID", "id", "id", "ID", "id", "Id"},
+	{"userID", "user_id", "user-id", "USER_ID", "userId", "UserId"},
+	{"HTTP2Server", "http2_server", "http2-server", "HTTP2_SERVER", "http2Server", "Http2Server"},
+	{"Int32Value", "int32_value", "int32-value", "INT32_VALUE", "int32Value", "Int32Value"},
+	{"base64Encode", "base64_encode", "base64-encode", "BASE64_ENCODE", "base64Encode", "Base64Encode"},
+	{"v2_3", "v2_3", "v2-3", "V2_3", "v23", "V23"},
+	{"ПриветМир", "привет_мир", "привет-мир", "ПРИВЕТ_МИР", "приветМир", "ПриветМир"},
+	{"größe_wert", "größe_wert", "größe-wert", "GRÖßE_WERT", "größeWert", "GrößeWert"},
+	{"日本語Text", "日本語_text", "日本語-text", "日本語_TEXT", "日本語Text", "日本語Text"},
+	{"bad\xffutf", "bad_utf", "bad-utf", "BAD_UTF", "badUtf", "BadUtf"},
+}
+
+func TestIdentCase(t *testing.T) {
+	for _, tt := range identCaseTests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.snake, ToSnakeCase(tt.s), "ToSnakeCase")
+			assert.Equal(t, tt.kebab, ToKebabCase(tt.s), "ToKebabCase")
+			assert.Equal(t, tt.screaming, ToScreamingSnake(tt.s), "ToScreamingSnake")
+			assert.Equal(t, tt.camel, ToCamelCase(tt.s), "ToCamelCase")
+			assert.Equal(t, tt.pascal, ToPascalCase(tt.s), "ToPascalCase")
+
+			var sb Builder
+			sb.WriteString("<")
+			sb.WriteSnakeCase(tt.s)
+			sb.WriteString("|")
+			sb.WriteKebabCase(tt.s)
+			sb.WriteString("|")
+			sb.WriteScreamingSnake(tt.s)
+			sb.WriteString("|")
+			sb.WriteCamelCase(tt.s)
+			sb.WriteString("|")
+			sb.WritePascalCase(tt.s)
+			sb.WriteString(">")
+			assert.Equal(t, "<"+tt.snake+"|"+tt.kebab+"|"+tt.screaming+"|"+tt.camel+"|"+tt.pascal+">", sb.String())
+		})
+	}
+}
+
+func TestIdentCase_Allocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = ToSnakeCase("http_server_id")
+		_ = ToSnakeCase("http_server_id__")
+		_ = ToKebabCase("http-server-id")
+		_ = ToScreamingSnake("HTTP_SERVER_ID")
+		_ = ToCamelCase("httpServerId")
+		_ = ToPascalCase("HttpServerId")
+	})
+	assert.Equal(t, 0.0, allocs)
+
+	var sb Builder
+	sb.Grow(64)
+	allocs = testing.AllocsPerRun(100, func() {
+		sb.Reset()
+		sb.WriteSnakeCase("HTTPServerID")
+		sb.WriteCamelCase("http_server_id")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkThis_ToSnakeCase(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = ToSnakeCase("HTTPServerID")
+	}
+}
+
+func BenchmarkThis_ToSnakeCase_Unchanged(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = ToSnakeCase("http_server_id")
+	}
+}
+
+func BenchmarkThis_Builder_WriteSnakeCase(b *testing.B) {
+	var sb Builder
+	sb.Grow(64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteSnakeCase("HTTPServerID")
+	}
+}
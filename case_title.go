@@ -0,0 +1,130 @@
+package stringutils
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseOptions is a set of language-specific special casing options for Capitalize and TitleCase
+type CaseOptions uint8
+
+const (
+	// CaseTurkish use Turkish and Azeri special casing (i <-> İ and ı <-> I)
+	CaseTurkish CaseOptions = 1 << iota
+	// CaseGreekFinalSigma lower capital sigma at the end of word to final sigma (ς instead of σ)
+	CaseGreekFinalSigma
+)
+
+const (
+	greekCapitalSigma = 'Σ'
+	greekFinalSigma   = 'ς'
+)
+
+func (opts CaseOptions) toTitle(r rune) rune {
+	if opts&CaseTurkish != 0 {
+		return unicode.TurkishCase.ToTitle(r)
+	}
+	return unicode.ToTitle(r)
+}
+
+func (opts CaseOptions) toLower(r rune) rune {
+	if opts&CaseTurkish != 0 {
+		return unicode.TurkishCase.ToLower(r)
+	}
+	return unicode.ToLower(r)
+}
+
+// isWordRune reports whether r is a part of word for TitleCase (letters, digits, marks, underscore and apostrophes).
+func isWordRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return identClass[r] != 0 || r == '_' || r == '\''
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '’'
+}
+
+// isFinalSigma reports whether sigma, followed by s, is at the end of word.
+func isFinalSigma(s string) bool {
+	if s == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return !unicode.IsLetter(r) && !unicode.IsMark(r)
+}
+
+// WriteCapitalized appends s with the first rune mapped to title case (other runes are unchanged).
+// Invalid UTF-8 is written unchanged, like in Capitalize.
+func (sb *Builder) WriteCapitalized(s string, opts CaseOptions) {
+	if s == "" {
+		return
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if t := opts.toTitle(r); t != r {
+		sb.reserve(len(s) + utf8.UTFMax)
+		_, _ = sb.WriteRune(t)
+		s = s[size:]
+	}
+	sb.WriteString(s)
+}
+
+// WriteTitleCase appends s with the first rune of each word mapped to title case and other runes mapped to lower case.
+// Words are sequences of letters, digits, underscores and apostrophes (so "don't" is a single word).
+// Invalid UTF-8 is written unchanged (and breaks word), like in WriteCapitalized.
+func (sb *Builder) WriteTitleCase(s string, opts CaseOptions) {
+	sb.reserve(len(s) + utf8.UTFMax)
+	inWord := false
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		next := i + size
+		if !isWordRune(r) {
+			// also invalid UTF-8 (RuneError is not a word rune)
+			inWord = false
+			sb.WriteString(s[i:next])
+			i = next
+			continue
+		}
+		var t rune
+		if !inWord {
+			inWord = true
+			t = opts.toTitle(r)
+		} else if r == greekCapitalSigma && opts&CaseGreekFinalSigma != 0 && isFinalSigma(s[next:]) {
+			t = greekFinalSigma
+		} else {
+			t = opts.toLower(r)
+		}
+		if t == r {
+			sb.WriteString(s[i:next])
+		} else {
+			_, _ = sb.WriteRune(t)
+		}
+		i = next
+	}
+}
+
+// Capitalize returns s with the first rune mapped to title case (other runes are unchanged), a replacement for deprecated strings.Title for single word.
+// If s is already capitalized, it's returned without allocation.
+// Invalid UTF-8 is returned unchanged, like in WriteCapitalized.
+func Capitalize(s string, opts CaseOptions) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	t := opts.toTitle(r)
+	if t == r {
+		return s
+	}
+	var sb Builder
+	sb.Grow(len(s) + utf8.UTFMax)
+	_, _ = sb.WriteRune(t)
+	sb.WriteString(s[size:])
+	return sb.String()
+}
+
+// TitleCase returns s with the first rune of each word mapped to title case and other runes mapped to lower case,
+// a replacement for deprecated strings.Title.
+// Words are sequences of letters, digits, underscores and apostrophes (so "don't" is a single word).
+// Invalid UTF-8 is returned unchanged, like in Capitalize.
+func TitleCase(s string, opts CaseOptions) string {
+	var sb Builder
+	sb.WriteTitleCase(s, opts)
+	return sb.String()
+}
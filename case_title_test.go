@@ -0,0 +1,104 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapitalize(t *testing.T) {
+	tests := []struct {
+		s    string
+		opts CaseOptions
+		want string
+	}{
+		{s: "", want: ""},
+		{s: "hello world", want: "Hello world"},
+		{s: "Hello", want: "Hello"},
+		{s: "hELLO", want: "HELLO"},
+		{s: "123abc", want: "123abc"},
+		{s: "привет", want: "Привет"},
+		{s: "ǆemal", want: "ǅemal"},
+		{s: "istanbul", want: "Istanbul"},
+		{s: "istanbul", opts: CaseTurkish, want: "İstanbul"},
+		{s: "ılık", opts: CaseTurkish, want: "Ilık"},
+		{s: "\xffbad", want: "\xffbad"},
+		{s: "bad\xff", want: "Bad\xff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, Capitalize(tt.s, tt.opts))
+
+			var sb Builder
+			sb.WriteString("<")
+			sb.WriteCapitalized(tt.s, tt.opts)
+			assert.Equal(t, "<"+tt.want, sb.String())
+		})
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		opts CaseOptions
+		want string
+	}{
+		{s: "", want: ""},
+		{s: "hello world", want: "Hello World"},
+		{s: "HELLO WORLD", want: "Hello World"},
+		{s: "  multiple   spaces\ttab", want: "  Multiple   Spaces\tTab"},
+		{s: "don't stop", want: "Don't Stop"},
+		{s: "don’t stop", want: "Don’t Stop"},
+		{s: "snake_case and kebab-case", want: "Snake_case And Kebab-Case"},
+		{s: "a1b2 3c", want: "A1b2 3c"},
+		{s: "привет, МИР!", want: "Привет, Мир!"},
+		{s: "ǆungla", want: "ǅungla"},
+		{s: "iIıİ istanbul", want: "Iiıi Istanbul"},
+		{s: "iIıİ istanbul", opts: CaseTurkish, want: "İııi İstanbul"},
+		{s: "ΟΔΥΣΣΕΥΣ ΣΟΦΟΣ", want: "Οδυσσευσ Σοφοσ"},
+		{s: "ΟΔΥΣΣΕΥΣ ΣΟΦΟΣ.", opts: CaseGreekFinalSigma, want: "Οδυσσευς Σοφος."},
+		{s: "ΑΣ ΣΑ", opts: CaseGreekFinalSigma, want: "Ας Σα"},
+		{s: "\xffbad x", want: "\xffBad X"},
+		{s: "ab\xffCD \xe2\x80", want: "Ab\xffCd \xe2\x80"},
+		{s: "\uFFFDbad", want: "\uFFFDBad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, TitleCase(tt.s, tt.opts))
+
+			var sb Builder
+			sb.WriteString("<")
+			sb.WriteTitleCase(tt.s, tt.opts)
+			assert.Equal(t, "<"+tt.want, sb.String())
+		})
+	}
+}
+
+func TestCapitalize_Allocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Capitalize("Hello", 0)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkThis_TitleCase(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = TitleCase("the quick brown fox", 0)
+	}
+}
+
+func BenchmarkThis_Builder_WriteTitleCase(b *testing.B) {
+	var sb Builder
+	sb.Grow(64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteTitleCase("the quick brown fox", 0)
+	}
+}
@@ -0,0 +1,232 @@
+package stringutils
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrCSVBareQuote is returned when a quote appears in an unquoted CSV field
+	ErrCSVBareQuote = errors.New("stringutils: bare \" in non-quoted CSV field")
+	// ErrCSVQuote is returned when a quoted CSV field is not terminated or has extraneous chars after closing quote
+	ErrCSVQuote = errors.New("stringutils: extraneous or missing \" in quoted CSV field")
+)
+
+// CSVWriter is a CSV (RFC 4180) or TSV record encoder over Builder.
+// CSV fields are quoted only when needed. In TSV mode fields are never quoted, special chars are escaped with backslash (\t, \n, \r, \\).
+type CSVWriter struct {
+	sb      *Builder
+	delim   byte
+	tsv     bool
+	useCRLF bool
+	fields  int
+}
+
+// NewCSVWriter return new CSVWriter with delim (',' if delim is 0), appended to sb.
+func NewCSVWriter(sb *Builder, delim byte) *CSVWriter {
+	if delim == 0 {
+		delim = ','
+	}
+	return &CSVWriter{sb: sb, delim: delim}
+}
+
+// NewTSVWriter return new CSVWriter in TSV mode (tab-delimited, backslash escaped), appended to sb.
+func NewTSVWriter(sb *Builder) *CSVWriter {
+	return &CSVWriter{sb: sb, delim: '\t', tsv: true}
+}
+
+// SetCRLF set \r\n as record terminator (\n by default).
+func (w *CSVWriter) SetCRLF(useCRLF bool) {
+	w.useCRLF = useCRLF
+}
+
+// Reset resets the CSVWriter state and set Builder for append.
+func (w *CSVWriter) Reset(sb *Builder) {
+	w.sb = sb
+	w.fields = 0
+}
+
+// Builder return underlying Builder.
+func (w *CSVWriter) Builder() *Builder {
+	return w.sb
+}
+
+func (w *CSVWriter) sep() {
+	if w.fields > 0 {
+		_ = w.sb.WriteByte(w.delim)
+	}
+	w.fields++
+}
+
+// fieldNeedsQuotes reports whether our field must be enclosed in quotes (like encoding/csv).
+func (w *CSVWriter) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field[0] == ' ' || field[0] == '\t' {
+		return true
+	}
+	for i := 0; i < len(field); i++ {
+		if c := field[i]; c == w.delim || c == '"' || c == '\r' || c == '\n' {
+			return true
+		}
+	}
+	return false
+}
+
+// Field writes string field.
+func (w *CSVWriter) Field(s string) {
+	w.sep()
+	if w.tsv {
+		w.sb.writeTSVEscaped(s)
+	} else if !w.fieldNeedsQuotes(s) {
+		w.sb.WriteString(s)
+	} else {
+		w.sb.reserve(len(s) + 2)
+		_ = w.sb.WriteByte('"')
+		for {
+			i := strings.IndexByte(s, '"')
+			if i == -1 {
+				break
+			}
+			w.sb.WriteString(s[:i+1])
+			_ = w.sb.WriteByte('"')
+			s = s[i+1:]
+		}
+		w.sb.WriteString(s)
+		_ = w.sb.WriteByte('"')
+	}
+}
+
+// FieldInt writes integer field.
+func (w *CSVWriter) FieldInt(i int64) {
+	w.sep()
+	w.sb.WriteInt(i, 10)
+}
+
+// FieldUint writes unsigned integer field.
+func (w *CSVWriter) FieldUint(u uint64) {
+	w.sep()
+	w.sb.WriteUint(u, 10)
+}
+
+// FieldFloat writes floating-point field (with the minimal number of digits).
+func (w *CSVWriter) FieldFloat(f float64) {
+	w.sep()
+	w.sb.WriteFloat(f, 'f', -1, 64)
+}
+
+// EndRecord ends the current record.
+func (w *CSVWriter) EndRecord() {
+	if w.useCRLF {
+		_ = w.sb.WriteByte('\r')
+	}
+	_ = w.sb.WriteByte('\n')
+	w.fields = 0
+}
+
+// WriteRecord writes fields as single record.
+func (w *CSVWriter) WriteRecord(fields []string) {
+	for _, s := range fields {
+		w.Field(s)
+	}
+	w.EndRecord()
+}
+
+// writeTSVEscaped appends s with \t, \n, \r and \\ escaped by backslash.
+func (sb *Builder) writeTSVEscaped(s string) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		var e byte
+		switch s[i] {
+		case '\t':
+			e = 't'
+		case '\n':
+			e = 'n'
+		case '\r':
+			e = 'r'
+		case '\\':
+			e = '\\'
+		default:
+			continue
+		}
+		sb.WriteString(s[start:i])
+		_ = sb.WriteByte('\\')
+		_ = sb.WriteByte(e)
+		start = i + 1
+	}
+	sb.WriteString(s[start:])
+}
+
+// SplitCSV return splitted CSV record (use pre-allocated buffer) (realloc if needed).
+// Trailing new line is trimmed. Fields are substrings of line, so allocation is only made for quoted fields with escaped ("") quotes.
+func SplitCSV(line string, delim byte, buf []string) ([]string, error) {
+	buf = buf[:0]
+	line = TrimRight(line, '\n')
+	line = TrimRight(line, '\r')
+
+	for {
+		if len(line) == 0 || line[0] != '"' {
+			// non-quoted field
+			pos := strings.IndexByte(line, delim)
+			field := line
+			if pos != -1 {
+				field = line[:pos]
+			}
+			if strings.IndexByte(field, '"') != -1 {
+				return buf, ErrCSVBareQuote
+			}
+			buf = append(buf, field)
+			if pos == -1 {
+				return buf, nil
+			}
+			line = line[pos+1:]
+			continue
+		}
+
+		// quoted field
+		line = line[1:]
+		var (
+			sb    *Builder // for unescaped field with "" quotes
+			start int
+			pos   int
+		)
+		for {
+			i := strings.IndexByte(line[pos:], '"')
+			if i == -1 {
+				return buf, ErrCSVQuote
+			}
+			pos += i
+			if pos+1 < len(line) && line[pos+1] == '"' {
+				// escaped quote
+				if sb == nil {
+					sb = &Builder{}
+					sb.Grow(len(line))
+				}
+				sb.WriteString(line[start : pos+1])
+				pos += 2
+				start = pos
+				continue
+			}
+			break
+		}
+		if sb == nil {
+			buf = append(buf, line[:pos])
+		} else {
+			sb.WriteString(line[start:pos])
+			buf = append(buf, sb.String())
+		}
+		line = line[pos+1:]
+		if len(line) == 0 {
+			return buf, nil
+		}
+		if line[0] != delim {
+			return buf, ErrCSVQuote
+		}
+		line = line[1:]
+		if len(line) == 0 {
+			// trailing delimiter, so last field is empty
+			return append(buf, ""), nil
+		}
+	}
+}
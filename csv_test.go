@@ -0,0 +1,154 @@
+package stringutils
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriter(t *testing.T) {
+	records := [][]string{
+		{"a", "b c", ""},
+		{"with,comma", "with \"quote\"", "multi\nline"},
+		{" leading space", "\ttab", "cr\r"},
+		{"тест", "\"", "end"},
+	}
+
+	var sb Builder
+	w := NewCSVWriter(&sb, 0)
+	for _, r := range records {
+		w.WriteRecord(r)
+	}
+
+	var want strings.Builder
+	cw := csv.NewWriter(&want)
+	assert.NoError(t, cw.WriteAll(records))
+	assert.Equal(t, want.String(), sb.String())
+
+	got, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, records, got)
+
+	sb.Reset()
+	w = NewCSVWriter(&sb, ';')
+	w.SetCRLF(true)
+	w.Field("a;b")
+	w.FieldInt(-1)
+	w.FieldUint(2)
+	w.FieldFloat(3.5)
+	w.Field("c,d")
+	w.EndRecord()
+	assert.Equal(t, "\"a;b\";-1;2;3.5;c,d\r\n", sb.String())
+}
+
+func TestTSVWriter(t *testing.T) {
+	var sb Builder
+	w := NewTSVWriter(&sb)
+	w.WriteRecord([]string{"a\tb", "c\nd", "e\\f\r", "\"g\""})
+	w.Field("")
+	w.FieldInt(1)
+	w.EndRecord()
+	assert.Equal(t, "a\\tb\tc\\nd\te\\\\f\\r\t\"g\"\n\t1\n", sb.String())
+}
+
+func TestSplitCSV(t *testing.T) {
+	tests := []struct {
+		line    string
+		delim   byte
+		want    []string
+		wantErr error
+	}{
+		{line: "", delim: ',', want: []string{""}},
+		{line: "a,b,c\n", delim: ',', want: []string{"a", "b", "c"}},
+		{line: "a,b,c\r\n", delim: ',', want: []string{"a", "b", "c"}},
+		{line: "a,,", delim: ',', want: []string{"a", "", ""}},
+		{line: `"a,b",c`, delim: ',', want: []string{"a,b", "c"}},
+		{line: `"",""`, delim: ',', want: []string{"", ""}},
+		{line: `"a",`, delim: ',', want: []string{"a", ""}},
+		{line: `"say ""hi""",x`, delim: ',', want: []string{`say "hi"`, "x"}},
+		{line: `""""`, delim: ',', want: []string{`"`}},
+		{line: "\"multi\nline\";b", delim: ';', want: []string{"multi\nline", "b"}},
+		{line: "a\tb c\t\"d\te\"", delim: '\t', want: []string{"a", "b c", "d\te"}},
+		{line: `a"b,c`, delim: ',', want: nil, wantErr: ErrCSVBareQuote},
+		{line: `"abc`, delim: ',', want: nil, wantErr: ErrCSVQuote},
+		{line: `"a"b,c`, delim: ',', want: []string{"a"}, wantErr: ErrCSVQuote},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.line), func(t *testing.T) {
+			got, err := SplitCSV(tt.line, tt.delim, nil)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+
+			if err == nil {
+				r := csv.NewReader(strings.NewReader(tt.line))
+				r.Comma = rune(tt.delim)
+				if want, err := r.Read(); err == nil {
+					assert.Equal(t, want, got, "encoding/csv")
+				}
+			}
+		})
+	}
+}
+
+func TestSplitCSV_RoundTrip(t *testing.T) {
+	record := []string{"a", "b,c", `"d"`, "", " e", "f\ng"}
+	var sb Builder
+	w := NewCSVWriter(&sb, ',')
+	w.WriteRecord(record)
+
+	got, err := SplitCSV(sb.String(), ',', make([]string, 0, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, record, got)
+}
+
+func TestSplitCSV_Allocs(t *testing.T) {
+	buf := make([]string, 0, 8)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = SplitCSV(`a,"b,c",,"d"`+"\n", ',', buf)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkThis_CSVWriter(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	w := NewCSVWriter(&sb, ',')
+	record := []string{"test.metric", "with,comma", "with \"quote\"", "12.5"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		w.WriteRecord(record)
+	}
+}
+
+func BenchmarkThis_SplitCSV(b *testing.B) {
+	line := `test.metric,"with,comma",12.5,1667464245` + "\n"
+	buf := make([]string, 0, 8)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf, _ = SplitCSV(line, ',', buf)
+	}
+}
+
+func BenchmarkStd_CSVReader(b *testing.B) {
+	line := `test.metric,"with,comma",12.5,1667464245` + "\n"
+	r := strings.NewReader(line)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r.Reset(line)
+		cr := csv.NewReader(r)
+		_, _ = cr.Read()
+	}
+}
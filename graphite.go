@@ -0,0 +1,214 @@
+package stringutils
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrGraphiteLine is returned when Graphite plaintext line is malformed
+	ErrGraphiteLine = errors.New("stringutils: invalid graphite line")
+	// ErrGraphiteValue is returned when Graphite plaintext line has invalid value
+	ErrGraphiteValue = errors.New("stringutils: invalid graphite value")
+	// ErrGraphiteTimestamp is returned when Graphite plaintext line has invalid timestamp
+	ErrGraphiteTimestamp = errors.New("stringutils: invalid graphite timestamp")
+)
+
+// WriteGraphiteLine appends Graphite plaintext protocol line "path value timestamp\n".
+// NaN is written as "nan", infinities as "inf" and "-inf" (as carbon accepts them).
+func (sb *Builder) WriteGraphiteLine(path string, value float64, ts int64) {
+	sb.reserve(len(path) + 32)
+	sb.WriteString(path)
+	_ = sb.WriteByte(' ')
+	switch {
+	case math.IsNaN(value):
+		sb.WriteString("nan")
+	case math.IsInf(value, 1):
+		sb.WriteString("inf")
+	case math.IsInf(value, -1):
+		sb.WriteString("-inf")
+	default:
+		sb.WriteFloat(value, 'f', -1, 64)
+	}
+	_ = sb.WriteByte(' ')
+	sb.WriteInt(ts, 10)
+	_ = sb.WriteByte('\n')
+}
+
+// ParseGraphiteLine parse Graphite plaintext protocol line "path value timestamp" (without memory allocations).
+// Trailing "\n" or "\r\n" is trimmed, fields can be separated by multiple spaces.
+// Returned path is a substring of line.
+// Value can be "nan", "inf" and "-inf" (case-insensitive), timestamp can be float (fraction is truncated).
+func ParseGraphiteLine(line string) (path string, value float64, ts int64, err error) {
+	line = TrimRight(line, '\n')
+	line = TrimRight(line, '\r')
+
+	var (
+		s string
+		n int
+	)
+	path, s, n = Split2(line, " ")
+	if n == 1 || path == "" {
+		return "", 0, 0, ErrGraphiteLine
+	}
+	s = TrimLeft(s, ' ')
+	vs, s, n := Split2(s, " ")
+	if n == 1 || vs == "" {
+		return "", 0, 0, ErrGraphiteLine
+	}
+	tss := Trim(s, ' ')
+	if tss == "" || strings.IndexByte(tss, ' ') != -1 {
+		return "", 0, 0, ErrGraphiteLine
+	}
+
+	if value, err = parseGraphiteValue(vs); err != nil {
+		return "", 0, 0, err
+	}
+	if ts, err = parseGraphiteTimestamp(tss); err != nil {
+		return "", 0, 0, err
+	}
+
+	return path, value, ts, nil
+}
+
+func parseGraphiteValue(s string) (float64, error) {
+	switch {
+	case EqualFold(s, "nan"):
+		return math.NaN(), nil
+	case EqualFold(s, "inf") || EqualFold(s, "+inf"):
+		return math.Inf(1), nil
+	case EqualFold(s, "-inf"):
+		return math.Inf(-1), nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, ErrGraphiteValue
+	}
+	return v, nil
+}
+
+func parseGraphiteTimestamp(s string) (int64, error) {
+	// fast path for integer timestamp
+	var ts int64
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			// float or negative timestamp
+			return parseGraphiteTimestampSlow(s)
+		}
+		d := int64(c - '0')
+		if ts > (math.MaxInt64-d)/10 {
+			return 0, ErrGraphiteTimestamp
+		}
+		ts = ts*10 + d
+	}
+	return ts, nil
+}
+
+func parseGraphiteTimestampSlow(s string) (int64, error) {
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ts, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, ErrGraphiteTimestamp
+	}
+	return int64(f), nil
+}
+
+// pickle protocol 2 opcodes, used for Graphite pickle protocol
+const (
+	pickleProto      = 0x80
+	pickleEmptyList  = ']'
+	pickleMark       = '('
+	pickleAppends    = 'e'
+	pickleTuple2     = 0x86
+	pickleBinUnicode = 'X'
+	pickleBinInt     = 'J'
+	pickleLong1      = 0x8a
+	pickleBinFloat   = 'G'
+	pickleStop       = '.'
+)
+
+// GraphitePickleWriter is a Graphite pickle protocol encoder over Builder (for carbon pickle receiver).
+// Batch is a 4-byte big-endian length header and pickled (protocol 2) list of (path, (timestamp, value)) tuples:
+//
+//	w.Metric("a.b.c", 1.5, 1667464245)
+//	w.Metric("a.b.d", 2, 1667464245)
+//	w.End()
+//
+// Only encoding is supported (decoding of untrusted pickle data is out of scope).
+type GraphitePickleWriter struct {
+	sb      *Builder
+	start   int // batch start position
+	metrics int
+}
+
+// NewGraphitePickleWriter return new GraphitePickleWriter, appended to sb.
+func NewGraphitePickleWriter(sb *Builder) *GraphitePickleWriter {
+	return &GraphitePickleWriter{sb: sb}
+}
+
+// Reset resets the GraphitePickleWriter state and set Builder for append.
+func (w *GraphitePickleWriter) Reset(sb *Builder) {
+	w.sb = sb
+	w.metrics = 0
+}
+
+// Builder return underlying Builder.
+func (w *GraphitePickleWriter) Builder() *Builder {
+	return w.sb
+}
+
+// Metrics returns the number of metrics in the current batch.
+func (w *GraphitePickleWriter) Metrics() int {
+	return w.metrics
+}
+
+// Metric add metric to the current batch (batch is started by first metric). Path must be valid UTF-8.
+func (w *GraphitePickleWriter) Metric(path string, value float64, ts int64) {
+	sb := w.sb
+	if w.metrics == 0 {
+		w.start = sb.Len()
+		n := sb.extend(4) // length header, set by End
+		binary.BigEndian.PutUint32(sb.data[n:], 0)
+		sb.data = append(sb.data, pickleProto, 2, pickleEmptyList, pickleMark)
+	}
+	w.metrics++
+
+	sb.reserve(len(path) + 32)
+	sb.data = append(sb.data, pickleBinUnicode)
+	n := sb.extend(4)
+	binary.LittleEndian.PutUint32(sb.data[n:], uint32(len(path)))
+	sb.WriteString(path)
+
+	if ts >= math.MinInt32 && ts <= math.MaxInt32 {
+		sb.data = append(sb.data, pickleBinInt)
+		n = sb.extend(4)
+		binary.LittleEndian.PutUint32(sb.data[n:], uint32(int32(ts)))
+	} else {
+		sb.data = append(sb.data, pickleLong1, 8)
+		n = sb.extend(8)
+		binary.LittleEndian.PutUint64(sb.data[n:], uint64(ts))
+	}
+
+	sb.data = append(sb.data, pickleBinFloat)
+	n = sb.extend(8)
+	binary.BigEndian.PutUint64(sb.data[n:], math.Float64bits(value))
+
+	sb.data = append(sb.data, pickleTuple2, pickleTuple2)
+}
+
+// End ends the current batch and set its length header. Nothing is written for empty batch.
+func (w *GraphitePickleWriter) End() {
+	if w.metrics == 0 {
+		return
+	}
+	sb := w.sb
+	sb.data = append(sb.data, pickleAppends, pickleStop)
+	binary.BigEndian.PutUint32(sb.data[w.start:], uint32(len(sb.data)-w.start-4))
+	w.metrics = 0
+}
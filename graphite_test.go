@@ -0,0 +1,157 @@
+package stringutils
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_WriteGraphiteLine(t *testing.T) {
+	tests := []struct {
+		path  string
+		value float64
+		ts    int64
+		want  string
+	}{
+		{"a.b.c", 1, 1667464245, "a.b.c 1 1667464245\n"},
+		{"a.b.c", -1.5, 1667464245, "a.b.c -1.5 1667464245\n"},
+		{"a.b.c", 1e21, 0, "a.b.c 1000000000000000000000 0\n"},
+		{"a.b.c", 0.000001, 1, "a.b.c 0.000001 1\n"},
+		{"a.b.c", math.NaN(), 1, "a.b.c nan 1\n"},
+		{"a.b.c", math.Inf(1), 1, "a.b.c inf 1\n"},
+		{"a.b.c", math.Inf(-1), 1, "a.b.c -inf 1\n"},
+		{"a.b.c;tag=v", 2, -1, "a.b.c;tag=v 2 -1\n"},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sb.Reset()
+			sb.WriteGraphiteLine(tt.path, tt.value, tt.ts)
+			assert.Equal(t, tt.want, sb.String())
+
+			path, value, ts, err := ParseGraphiteLine(sb.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.path, path)
+			if math.IsNaN(tt.value) {
+				assert.True(t, math.IsNaN(value))
+			} else {
+				assert.Equal(t, tt.value, value)
+			}
+			assert.Equal(t, tt.ts, ts)
+		})
+	}
+}
+
+func TestParseGraphiteLine(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantPath  string
+		wantValue float64
+		wantTs    int64
+		wantErr   error
+	}{
+		{"a.b.c 1 1667464245", "a.b.c", 1, 1667464245, nil},
+		{"a.b.c 1.5 1667464245\r\n", "a.b.c", 1.5, 1667464245, nil},
+		{"a.b.c  -1e3   1667464245.789 \n", "a.b.c", -1000, 1667464245, nil},
+		{"a.b.c +Inf 1", "a.b.c", math.Inf(1), 1, nil},
+		{"a.b.c -INF -1", "a.b.c", math.Inf(-1), -1, nil},
+		{"a.b.c 1 9223372036854775807", "a.b.c", 1, math.MaxInt64, nil},
+		{"", "", 0, 0, ErrGraphiteLine},
+		{"a.b.c", "", 0, 0, ErrGraphiteLine},
+		{"a.b.c 1", "", 0, 0, ErrGraphiteLine},
+		{"a.b.c 1 ", "", 0, 0, ErrGraphiteLine},
+		{" a.b.c 1 2", "", 0, 0, ErrGraphiteLine},
+		{"a.b.c 1 2 3", "", 0, 0, ErrGraphiteLine},
+		{"a.b.c x 2", "", 0, 0, ErrGraphiteValue},
+		{"a.b.c 1 x", "", 0, 0, ErrGraphiteTimestamp},
+		{"a.b.c 1 nan", "", 0, 0, ErrGraphiteTimestamp},
+		{"a.b.c 1 1e30", "", 0, 0, ErrGraphiteTimestamp},
+		{"a.b.c 1 9223372036854775808", "", 0, 0, ErrGraphiteTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			path, value, ts, err := ParseGraphiteLine(tt.line)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantTs, ts)
+		})
+	}
+}
+
+func TestGraphitePickleWriter(t *testing.T) {
+	var sb Builder
+	w := NewGraphitePickleWriter(&sb)
+
+	// empty batch is not written
+	w.End()
+	assert.Equal(t, 0, sb.Len())
+
+	w.Metric("a.b.c", 1.5, 1667464245)
+	w.Metric("тест", math.Inf(-1), 1<<40)
+	w.Metric("x", -2, -5)
+	assert.Equal(t, 3, w.Metrics())
+	w.End()
+	assert.Equal(t, 0, w.Metrics())
+
+	sb2 := &Builder{}
+	w.Reset(sb2)
+	w.Metric("y", 0, 0)
+	w.End()
+	assert.Equal(t, sb2, w.Builder())
+
+	// verified with python pickle.loads:
+	// [('a.b.c', (1667464245, 1.5)), ('тест', (1099511627776, -inf)), ('x', (-5, -2.0))]
+	assert.Equal(t,
+		"0000005880025d285805000000612e622e634a357c6363473ff800000000000086865808000000d182d0b5d181d1828a0800000000000100"+
+			"0047fff000000000000086865801000000784afbffffff47c0000000000000008686652e",
+		hex.EncodeToString(sb.Bytes()),
+	)
+	// [('y', (0, 0.0))]
+	assert.Equal(t,
+		"0000001c80025d285801000000794a000000004700000000000000008686652e",
+		hex.EncodeToString(sb2.Bytes()),
+	)
+}
+
+func BenchmarkThis_ParseGraphiteLine(b *testing.B) {
+	line := "carbon.agents.host.metricsReceived 1234.5 1667464245\n"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = ParseGraphiteLine(line)
+	}
+}
+
+func BenchmarkThis_Builder_WriteGraphiteLine(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteGraphiteLine("carbon.agents.host.metricsReceived", 1234.5, 1667464245)
+	}
+}
+
+func BenchmarkThis_GraphitePickleWriter(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	w := NewGraphitePickleWriter(&sb)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		w.Metric("carbon.agents.host.metricsReceived", 1234.5, 1667464245)
+		w.Metric("carbon.agents.host.metricsSent", 1234, 1667464245)
+		w.End()
+	}
+}
@@ -0,0 +1,165 @@
+package stringutils
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// htmlEscape return escaped form of c (as in html.EscapeString) or empty string.
+func htmlEscape(c byte) string {
+	switch c {
+	case '<':
+		return "&lt;"
+	case '>':
+		return "&gt;"
+	case '&':
+		return "&amp;"
+	case '\'':
+		return "&#39;"
+	case '"':
+		return "&#34;"
+	}
+	return ""
+}
+
+// xmlEscape return escaped form of ASCII byte c (as in xml.EscapeText) or empty string.
+func xmlEscape(c byte) string {
+	switch c {
+	case '\t':
+		return "&#x9;"
+	case '\n':
+		return "&#xA;"
+	case '\r':
+		return "&#xD;"
+	}
+	return htmlEscape(c)
+}
+
+// WriteHTMLEscaped appends s with special characters (<, >, &, ' and ") escaped, as generated by html.EscapeString.
+func (sb *Builder) WriteHTMLEscaped(s string) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if e := htmlEscape(s[i]); e != "" {
+			sb.WriteString(s[start:i])
+			sb.WriteString(e)
+			start = i + 1
+		}
+	}
+	sb.WriteString(s[start:])
+}
+
+// isXMLChar reports whether r is in the XML Char production.
+func isXMLChar(r rune) bool {
+	return r == 0x09 ||
+		r == 0x0A ||
+		r == 0x0D ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
+
+// WriteXMLEscaped appends s with special characters escaped, as generated by xml.EscapeText.
+// Characters outside the XML Char production and invalid UTF-8 sequences are replaced with U+FFFD.
+func (sb *Builder) WriteXMLEscaped(s string) {
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if e := xmlEscape(c); e != "" {
+				sb.WriteString(s[start:i])
+				sb.WriteString(e)
+				start = i + 1
+			} else if c < ' ' {
+				sb.WriteString(s[start:i])
+				sb.WriteString("\uFFFD")
+				start = i + 1
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if (r == utf8.RuneError && size == 1) || !isXMLChar(r) {
+			sb.WriteString(s[start:i])
+			sb.WriteString("\uFFFD")
+			start = i + size
+		}
+		i += size
+	}
+	sb.WriteString(s[start:])
+}
+
+// EscapeHTML escapes special characters like "<" to become "&lt;", like html.EscapeString.
+// Also return change flag. If s has no special characters, it's returned unchanged (without allocation).
+func EscapeHTML(s string) (string, bool) {
+	i := 0
+	for ; i < len(s); i++ {
+		if htmlEscape(s[i]) != "" {
+			break
+		}
+	}
+	if i == len(s) {
+		return s, false
+	}
+	var sb Builder
+	sb.Grow(len(s) + len(s)/4 + 4)
+	sb.WriteString(s[:i])
+	sb.WriteHTMLEscaped(s[i:])
+	return sb.String(), true
+}
+
+// EscapeXML escapes special characters, like xml.EscapeText.
+// Also return change flag. If s has no special characters, it's returned unchanged (without allocation).
+func EscapeXML(s string) (string, bool) {
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c < ' ' || xmlEscape(c) != "" {
+				break
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if (r == utf8.RuneError && size == 1) || !isXMLChar(r) {
+			break
+		}
+		i += size
+	}
+	if i == len(s) {
+		return s, false
+	}
+	var sb Builder
+	sb.Grow(len(s) + len(s)/4 + 4)
+	sb.WriteString(s[:i])
+	sb.WriteXMLEscaped(s[i:])
+	return sb.String(), true
+}
+
+// UnescapeHTML unescapes entities like "&lt;" to become "<", like html.UnescapeString (used for decoding,
+// so all HTML5 named and numeric character references are supported).
+// Also return change flag. If s has no entities, it's returned unchanged (without allocation,
+// if there is no '&' followed by '#' or alphanumeric char).
+func UnescapeHTML(s string) (string, bool) {
+	i := strings.IndexByte(s, '&')
+	for i != -1 {
+		if i+1 < len(s) {
+			if c := s[i+1]; c == '#' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+				break
+			}
+		}
+		if n := strings.IndexByte(s[i+1:], '&'); n == -1 {
+			i = -1
+		} else {
+			i += n + 1
+		}
+	}
+	if i == -1 {
+		return s, false
+	}
+	if u := html.UnescapeString(s); u != s {
+		return u, true
+	}
+	return s, false
+}
@@ -0,0 +1,149 @@
+package stringutils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var htmlEscapeTests = []string{
+	"",
+	"plain text",
+	"<b>bold</b>",
+	`a & b "c" 'd'`,
+	"тест <世界>",
+	"tab\tnew\nline\r",
+	"ctrl\x00\x1f",
+	"bad\xffutf",
+	"\uFFFE",
+}
+
+func TestEscapeHTML(t *testing.T) {
+	for _, s := range htmlEscapeTests {
+		t.Run(strconv.Quote(s), func(t *testing.T) {
+			want := html.EscapeString(s)
+			got, changed := EscapeHTML(s)
+			assert.Equal(t, want, got)
+			assert.Equal(t, want != s, changed)
+
+			var sb Builder
+			sb.WriteHTMLEscaped(s)
+			assert.Equal(t, want, sb.String())
+		})
+	}
+}
+
+func TestEscapeXML(t *testing.T) {
+	for _, s := range htmlEscapeTests {
+		t.Run(strconv.Quote(s), func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, xml.EscapeText(&buf, []byte(s)))
+			want := buf.String()
+			got, changed := EscapeXML(s)
+			assert.Equal(t, want, got)
+			assert.Equal(t, want != s, changed)
+
+			var sb Builder
+			sb.WriteXMLEscaped(s)
+			assert.Equal(t, want, sb.String())
+		})
+	}
+}
+
+func TestUnescapeHTML(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		changed bool
+	}{
+		{s: "", want: ""},
+		{s: "plain text", want: "plain text"},
+		{s: "a & b", want: "a & b"},
+		{s: "&unknown; &", want: "&unknown; &"},
+		{s: "&lt;b&gt;bold&lt;/b&gt;", want: "<b>bold</b>", changed: true},
+		{s: "&amp;amp;", want: "&amp;", changed: true},
+		{s: "&#34;q&#39;", want: `"q'`, changed: true},
+		{s: "&#x4e16;&#X754C;", want: "世界", changed: true},
+		{s: "a&nbsp;b &copy; &euro;5 &mdash;", want: "a\u00A0b © €5 —", changed: true},
+		{s: "&#0;&#xD800;&#1114112;", want: "\uFFFD\uFFFD\uFFFD", changed: true},
+		{s: "& &lt;", want: "& <", changed: true},
+		{s: "&#65", want: "A", changed: true},
+		{s: "&ampx &lt", want: "&x <", changed: true},
+		{s: "&#128;&#x9F;", want: "€Ÿ", changed: true},
+		{s: "&#x; &#;", want: "\uFFFD &#;", changed: true},
+		{s: "&AMP; &notin; &notit; &NotEqualTilde;", want: "& ∉ ¬it; \u2242\u0338", changed: true},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.s), func(t *testing.T) {
+			got, changed := UnescapeHTML(tt.s)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.changed, changed)
+			assert.Equal(t, html.UnescapeString(tt.s), got, "html")
+		})
+	}
+}
+
+func TestHTML_RoundTrip(t *testing.T) {
+	for _, s := range htmlEscapeTests {
+		escaped, _ := EscapeHTML(s)
+		got, _ := UnescapeHTML(escaped)
+		assert.Equal(t, s, got)
+	}
+}
+
+func TestHTML_Allocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = EscapeHTML("plain text")
+		_, _ = EscapeXML("plain text")
+		_, _ = UnescapeHTML("a & b")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkThis_EscapeHTML(b *testing.B) {
+	s := `<text x="10">cpu.usage & load</text>`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = EscapeHTML(s)
+	}
+}
+
+func BenchmarkStd_EscapeHTML(b *testing.B) {
+	s := `<text x="10">cpu.usage & load</text>`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = html.EscapeString(s)
+	}
+}
+
+func BenchmarkThis_EscapeHTML_Unchanged(b *testing.B) {
+	s := "cpu.usage.user"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = EscapeHTML(s)
+	}
+}
+
+func BenchmarkStd_EscapeHTML_Unchanged(b *testing.B) {
+	s := "cpu.usage.user"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = html.EscapeString(s)
+	}
+}
@@ -0,0 +1,278 @@
+package stringutils
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidNumber is returned when string can't be parsed as human-readable number
+	ErrInvalidNumber = errors.New("stringutils: invalid number")
+	// ErrInvalidUnit is returned when human-readable number has unknown unit or SI prefix
+	ErrInvalidUnit = errors.New("stringutils: invalid unit")
+	// ErrOverflow is returned when human-readable number is out of range
+	ErrOverflow = errors.New("stringutils: value out of range")
+)
+
+var (
+	bytesSizeIEC = [...]string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	bytesSizeSI  = [...]string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+
+	// SI prefixes from 1e-24 to 1e24
+	siPrefixes = [...]string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+)
+
+const siPrefixZero = 8 // index of empty prefix in siPrefixes
+
+// WriteIntGrouped appends the decimal form of the integer i with digits grouped by thousands with sep (1,234,567).
+func (sb *Builder) WriteIntGrouped(i int64, sep string) {
+	var buf [20]byte
+	num := strconv.AppendInt(buf[:0], i, 10)
+	digits := num
+	if i < 0 {
+		digits = num[1:]
+	}
+	groups := (len(digits) - 1) / 3
+	if groups == 0 || len(sep) == 0 {
+		sb.WriteBytes(num)
+		return
+	}
+
+	pos := sb.extend(len(num) + groups*len(sep))
+	b := sb.data[pos:]
+	if i < 0 {
+		b[0] = '-'
+		b = b[1:]
+	}
+	// first group can be shorter
+	first := len(digits) - groups*3
+	p := copy(b, digits[:first])
+	for j := first; j < len(digits); j += 3 {
+		p += copy(b[p:], sep)
+		p += copy(b[p:], digits[j:j+3])
+	}
+}
+
+// roundUnit return true if v, formatted with precision prec, will be rounded to base or above.
+func roundUnit(v float64, prec int, base float64) bool {
+	if prec < 0 {
+		return v >= base
+	}
+	p := math.Pow10(prec)
+	return math.Round(v*p)/p >= base
+}
+
+// WriteBytesSize appends human-readable size in bytes, like "1.5 GiB" (with IEC binary units) or "1.5 GB" (with SI units).
+// prec is the number of digits after the decimal point (-1 for the minimal number of digits, as in strconv.FormatFloat).
+// Size below 1 KiB (or 1 kB) is written as integer ("512 B").
+func (sb *Builder) WriteBytesSize(n uint64, iec bool, prec int) {
+	base := 1000.0
+	units := bytesSizeSI[:]
+	if iec {
+		base = 1024.0
+		units = bytesSizeIEC[:]
+	}
+	if float64(n) < base {
+		sb.WriteUint(n, 10)
+		_ = sb.WriteByte(' ')
+		sb.WriteString(units[0])
+		return
+	}
+	v := float64(n)
+	exp := 0
+	for (v >= base || roundUnit(v, prec, base)) && exp < len(units)-1 {
+		v /= base
+		exp++
+	}
+	sb.WriteFloat(v, 'f', prec, 64)
+	_ = sb.WriteByte(' ')
+	sb.WriteString(units[exp])
+}
+
+// WriteSI appends human-readable number with SI prefix, like "12.3k" or "1.5µ".
+// prec is the number of digits after the decimal point (-1 for the minimal number of digits, as in strconv.FormatFloat).
+func (sb *Builder) WriteSI(f float64, prec int) {
+	if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		sb.WriteFloat(f, 'f', prec, 64)
+		return
+	}
+	abs := math.Abs(f)
+	exp := int(math.Floor(math.Log10(abs) / 3))
+	if exp < -siPrefixZero {
+		exp = -siPrefixZero
+	} else if exp > siPrefixZero {
+		exp = siPrefixZero
+	}
+	v := abs / math.Pow10(exp*3)
+	if exp < siPrefixZero && roundUnit(v, prec, 1000) {
+		v /= 1000
+		exp++
+	}
+	if f < 0 {
+		v = -v
+	}
+	sb.WriteFloat(v, 'f', prec, 64)
+	sb.WriteString(siPrefixes[exp+siPrefixZero])
+}
+
+// splitNumber return leading decimal number (with optional sign and fraction) and unit (with leading spaces trimmed).
+func splitNumber(s string) (num, unit string) {
+	i := 0
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	for ; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && c != '.' {
+			break
+		}
+	}
+	num = s[:i]
+	return num, TrimLeft(s[i:], ' ')
+}
+
+// parseFloatExp parse num * 10^exp (without allocation and precision loss).
+func parseFloatExp(num string, exp int) (float64, error) {
+	if exp == 0 {
+		return strconv.ParseFloat(num, 64)
+	}
+	var buf [64]byte
+	if len(num) > len(buf)-8 {
+		return 0, ErrInvalidNumber
+	}
+	b := append(buf[:0], num...)
+	b = append(b, 'e')
+	b = strconv.AppendInt(b, int64(exp), 10)
+	return strconv.ParseFloat(UnsafeString(b), 64)
+}
+
+// ParseSI parse human-readable number with SI prefix, like "12.3k" or "1.5 µ" ('u' also accepted as micro prefix).
+func ParseSI(s string) (float64, error) {
+	num, unit := splitNumber(s)
+	if num == "" || num == "-" || num == "+" {
+		return 0, ErrInvalidNumber
+	}
+	exp := 0
+	switch unit {
+	case "":
+	case "u":
+		exp = -6
+	default:
+		exp = -1
+		for i, p := range siPrefixes {
+			if p == unit {
+				exp = (i - siPrefixZero) * 3
+				break
+			}
+		}
+		if exp == -1 {
+			return 0, ErrInvalidUnit
+		}
+	}
+	f, err := parseFloatExp(num, exp)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return f, ErrOverflow
+		}
+		return 0, ErrInvalidNumber
+	}
+	return f, nil
+}
+
+// ParseBytesSize parse human-readable size in bytes, like "1.5GiB", "1.5 GB" or "512".
+// Units are case-insensitive, IEC binary units (KiB, MiB, ..) are 1024-based, SI units (kB, MB, .. or K, M, ..) are 1000-based.
+// Size with unit, equal to 2^64 (like "16 EiB", written by WriteBytesSize for math.MaxUint64), is saturated to math.MaxUint64.
+func ParseBytesSize(s string) (uint64, error) {
+	num, unit := splitNumber(s)
+	if num == "" || num[0] == '-' || num == "+" {
+		return 0, ErrInvalidNumber
+	}
+
+	var (
+		base uint64 = 1000
+		exp  int
+	)
+	if len(unit) > 0 {
+		switch toLowerTable[unit[0]] {
+		case 'b':
+			exp = 0
+		case 'k':
+			exp = 1
+		case 'm':
+			exp = 2
+		case 'g':
+			exp = 3
+		case 't':
+			exp = 4
+		case 'p':
+			exp = 5
+		case 'e':
+			exp = 6
+		default:
+			return 0, ErrInvalidUnit
+		}
+		suffix := unit[1:]
+		if exp > 0 {
+			switch {
+			case suffix == "" || EqualFold(suffix, "b"):
+			case EqualFold(suffix, "ib"):
+				base = 1024
+			default:
+				return 0, ErrInvalidUnit
+			}
+		} else if suffix != "" {
+			return 0, ErrInvalidUnit
+		}
+	}
+
+	var mult uint64 = 1
+	for i := 0; i < exp; i++ {
+		mult *= base
+	}
+	if num[0] == '+' {
+		num = num[1:]
+	}
+	if strings.IndexByte(num, '.') == -1 {
+		// integer, so avoid float precision loss
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return 0, ErrOverflow
+		}
+		hi, lo := bits.Mul64(n, mult)
+		if hi == 0 {
+			return lo, nil
+		}
+		if hi == 1 && lo == 0 && exp > 0 {
+			return math.MaxUint64, nil
+		}
+		return 0, ErrOverflow
+	}
+	var (
+		f   float64
+		err error
+	)
+	if base == 1024 {
+		// multiply by power of 2 is exact
+		if f, err = strconv.ParseFloat(num, 64); err == nil {
+			f *= float64(mult)
+		}
+	} else {
+		f, err = parseFloatExp(num, exp*3)
+	}
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrOverflow
+		}
+		return 0, ErrInvalidNumber
+	}
+	if f = math.Round(f); f >= math.MaxUint64 {
+		// float64(math.MaxUint64) is 2^64
+		if f == math.MaxUint64 && exp > 0 {
+			return math.MaxUint64, nil
+		}
+		return 0, ErrOverflow
+	}
+	return uint64(f), nil
+}
@@ -0,0 +1,224 @@
+package stringutils
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_WriteIntGrouped(t *testing.T) {
+	tests := []struct {
+		i    int64
+		sep  string
+		want string
+	}{
+		{0, ",", "0"},
+		{999, ",", "999"},
+		{-999, ",", "-999"},
+		{1000, ",", "1,000"},
+		{-1234567, ",", "-1,234,567"},
+		{123456, " ", "123 456"},
+		{1234567, "", "1234567"},
+		{12345678, " ", "12 345 678"},
+		{math.MaxInt64, ",", "9,223,372,036,854,775,807"},
+		{math.MinInt64, "'", "-9'223'372'036'854'775'808"},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sb.Reset()
+			sb.WriteIntGrouped(tt.i, tt.sep)
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestBuilder_WriteBytesSize(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		iec  bool
+		prec int
+		want string
+	}{
+		{0, true, 1, "0 B"},
+		{1023, true, 1, "1023 B"},
+		{1024, true, 1, "1.0 KiB"},
+		{1536, true, -1, "1.5 KiB"},
+		{1610612736, true, 1, "1.5 GiB"},
+		{1048575, true, 1, "1.0 MiB"},
+		{1048575, true, 3, "1023.999 KiB"},
+		{999, false, 1, "999 B"},
+		{1500, false, 2, "1.50 kB"},
+		{999999, false, 1, "1.0 MB"},
+		{1500000000, false, -1, "1.5 GB"},
+		{math.MaxUint64, true, 1, "16.0 EiB"},
+		{math.MaxUint64, false, 1, "18.4 EB"},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sb.Reset()
+			sb.WriteBytesSize(tt.n, tt.iec, tt.prec)
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestBuilder_WriteSI(t *testing.T) {
+	tests := []struct {
+		f    float64
+		prec int
+		want string
+	}{
+		{0, 1, "0.0"},
+		{12, -1, "12"},
+		{12300, 1, "12.3k"},
+		{-12300, -1, "-12.3k"},
+		{999.96, 1, "1.0k"},
+		{1.5e6, -1, "1.5M"},
+		{0.0015, -1, "1.5m"},
+		{1.5e-6, -1, "1.5µ"},
+		{1e27, -1, "1000Y"},
+		{math.NaN(), 1, "NaN"},
+		{math.Inf(-1), 1, "-Inf"},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sb.Reset()
+			sb.WriteSI(tt.f, tt.prec)
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestParseSI(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    float64
+		wantErr error
+	}{
+		{"12", 12, nil},
+		{"12k", 12000, nil},
+		{"12.3k", 12300, nil},
+		{"-12.3 k", -12300, nil},
+		{"1.5M", 1.5e6, nil},
+		{"1.5m", 0.0015, nil},
+		{"1.5µ", 1.5e-6, nil},
+		{"1.5u", 1.5e-6, nil},
+		{"7Y", 7e24, nil},
+		{"", 0, ErrInvalidNumber},
+		{"k", 0, ErrInvalidNumber},
+		{"1.2.3k", 0, ErrInvalidNumber},
+		{"12x", 0, ErrInvalidUnit},
+		{"12kk", 0, ErrInvalidUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseSI(tt.s)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// round-trip
+	var sb Builder
+	for _, f := range []float64{1, 12.3, 12300, -4.56e-7, 7.89e12, 1.25e-20} {
+		sb.Reset()
+		sb.WriteSI(f, -1)
+		got, err := ParseSI(sb.String())
+		assert.NoError(t, err)
+		assert.Equal(t, f, got, sb.String())
+	}
+}
+
+func TestParseBytesSize(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    uint64
+		wantErr error
+	}{
+		{"0", 0, nil},
+		{"512", 512, nil},
+		{"512B", 512, nil},
+		{"512 b", 512, nil},
+		{"1.5GiB", 1610612736, nil},
+		{"1.5 gib", 1610612736, nil},
+		{"1.5 GB", 1500000000, nil},
+		{"10k", 10000, nil},
+		{"10KB", 10000, nil},
+		{"10KiB", 10240, nil},
+		{"+1MiB", 1048576, nil},
+		{"16EiB", math.MaxUint64, nil},
+		{"16.0 EiB", math.MaxUint64, nil},
+		{"16.1 EiB", 0, ErrOverflow},
+		{"17EiB", 0, ErrOverflow},
+		{"15EiB", 15 << 60, nil},
+		{"18446744073709551615", math.MaxUint64, nil},
+		{"18446744073709551616", 0, ErrOverflow},
+		{"18446744073709551616B", 0, ErrOverflow},
+		{"-1", 0, ErrInvalidNumber},
+		{"GiB", 0, ErrInvalidNumber},
+		{"1.5.1GiB", 0, ErrInvalidNumber},
+		{"1.5 XiB", 0, ErrInvalidUnit},
+		{"1.5 Gi", 0, ErrInvalidUnit},
+		{"1.5 BB", 0, ErrInvalidUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseBytesSize(tt.s)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// round-trip
+	var sb Builder
+	for _, n := range []uint64{0, 1000, 1024, 1536, 1610612736, 1500000000000, 3 << 40} {
+		for _, iec := range []bool{true, false} {
+			sb.Reset()
+			sb.WriteBytesSize(n, iec, -1)
+			got, err := ParseBytesSize(sb.String())
+			assert.NoError(t, err)
+			assert.Equal(t, n, got, sb.String()+" "+strconv.FormatBool(iec))
+		}
+	}
+
+	// top of the range
+	for _, prec := range []int{-1, 1} {
+		sb.Reset()
+		sb.WriteBytesSize(math.MaxUint64, true, prec)
+		got, err := ParseBytesSize(sb.String())
+		assert.NoError(t, err, sb.String())
+		assert.Equal(t, uint64(math.MaxUint64), got, sb.String())
+
+		sb.Reset()
+		sb.WriteBytesSize(math.MaxUint64, false, prec)
+		_, err = ParseBytesSize(sb.String())
+		assert.NoError(t, err, sb.String())
+	}
+}
+
+func BenchmarkThis_Builder_WriteBytesSize(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteBytesSize(1610612736, true, 1)
+	}
+}
+
+func BenchmarkThis_ParseBytesSize(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBytesSize("1.5 GiB")
+	}
+}
@@ -0,0 +1,226 @@
+package stringutils
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrLineProtocolNoFields is returned when InfluxDB line protocol point has no fields
+var ErrLineProtocolNoFields = errors.New("stringutils: line protocol point without fields")
+
+// ErrLineProtocolTagAfterField is returned when InfluxDB line protocol tag is added after field
+var ErrLineProtocolTagAfterField = errors.New("stringutils: line protocol tag after field")
+
+// ErrLineProtocolNewLine is returned when InfluxDB line protocol measurement, tag or field key contains new line (can't be escaped)
+var ErrLineProtocolNewLine = errors.New("stringutils: line protocol name with new line")
+
+type lineProtocolTag struct {
+	key, value string
+}
+
+// LineProtocolWriter is an InfluxDB line protocol encoder over Builder.
+// Point is written with Measurement, Tag (tags are sorted by key), Field* and End calls:
+//
+//	w.Measurement("cpu")
+//	w.Tag("host", "server01")
+//	w.FieldFloat("value", 0.64)
+//	w.End(time.Now())
+//
+// Tags must be added before fields and are buffered (without copy) until first field,
+// so tag strings must not be modified before.
+type LineProtocolWriter struct {
+	sb        *Builder
+	precision time.Duration
+	tags      []lineProtocolTag // reused between points
+	start     int               // point start position
+	fields    int
+	newLine   bool // new line in measurement or field key, point is invalid
+}
+
+// NewLineProtocolWriter return new LineProtocolWriter, appended to sb.
+// Timestamps are written with precision (time.Nanosecond, time.Microsecond, time.Millisecond or time.Second).
+func NewLineProtocolWriter(sb *Builder, precision time.Duration) *LineProtocolWriter {
+	if precision <= 0 {
+		precision = time.Nanosecond
+	}
+	return &LineProtocolWriter{sb: sb, precision: precision, tags: make([]lineProtocolTag, 0, 8)}
+}
+
+// Reset resets the LineProtocolWriter state and set Builder for append.
+func (w *LineProtocolWriter) Reset(sb *Builder) {
+	w.sb = sb
+	w.tags = w.tags[:0]
+	w.fields = 0
+	w.newLine = false
+}
+
+// Builder return underlying Builder.
+func (w *LineProtocolWriter) Builder() *Builder {
+	return w.sb
+}
+
+// Measurement starts a new point.
+// Name with new line is invalid, so point is removed by End.
+func (w *LineProtocolWriter) Measurement(name string) {
+	w.start = w.sb.Len()
+	w.tags = w.tags[:0]
+	w.fields = 0
+	w.newLine = hasNewLine(name)
+	w.sb.writeEscaped(name, lineProtocolEscapeMeasurement)
+}
+
+// Tag add tag to the current point. Tag with empty value is skipped (not allowed by line protocol).
+// Tag after first field is rejected with ErrLineProtocolTagAfterField, tag with new line with ErrLineProtocolNewLine.
+func (w *LineProtocolWriter) Tag(key, value string) error {
+	if w.fields > 0 {
+		return ErrLineProtocolTagAfterField
+	}
+	if hasNewLine(key) || hasNewLine(value) {
+		return ErrLineProtocolNewLine
+	}
+	if value != "" {
+		w.tags = append(w.tags, lineProtocolTag{key, value})
+	}
+	return nil
+}
+
+// beginField write sorted tags (before first field) or fields separator.
+func (w *LineProtocolWriter) beginField(key string) {
+	if w.fields == 0 {
+		// insertion sort, tags count is small
+		for i := 1; i < len(w.tags); i++ {
+			for j := i; j > 0 && w.tags[j].key < w.tags[j-1].key; j-- {
+				w.tags[j], w.tags[j-1] = w.tags[j-1], w.tags[j]
+			}
+		}
+		for i := range w.tags {
+			_ = w.sb.WriteByte(',')
+			w.sb.writeEscaped(w.tags[i].key, lineProtocolEscapeKey)
+			_ = w.sb.WriteByte('=')
+			w.sb.writeEscaped(w.tags[i].value, lineProtocolEscapeKey)
+		}
+		_ = w.sb.WriteByte(' ')
+	} else {
+		_ = w.sb.WriteByte(',')
+	}
+	w.fields++
+	if hasNewLine(key) {
+		w.newLine = true
+	}
+	w.sb.writeEscaped(key, lineProtocolEscapeKey)
+	_ = w.sb.WriteByte('=')
+}
+
+// FieldFloat add float field. NaN and Inf are not supported by line protocol, so field is skipped.
+func (w *LineProtocolWriter) FieldFloat(key string, value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	w.beginField(key)
+	w.sb.WriteFloat(value, 'g', -1, 64)
+}
+
+// FieldInt add integer field (with i suffix).
+func (w *LineProtocolWriter) FieldInt(key string, value int64) {
+	w.beginField(key)
+	w.sb.WriteInt(value, 10)
+	_ = w.sb.WriteByte('i')
+}
+
+// FieldUint add unsigned integer field (with u suffix).
+func (w *LineProtocolWriter) FieldUint(key string, value uint64) {
+	w.beginField(key)
+	w.sb.WriteUint(value, 10)
+	_ = w.sb.WriteByte('u')
+}
+
+// FieldBool add boolean field.
+func (w *LineProtocolWriter) FieldBool(key string, value bool) {
+	w.beginField(key)
+	w.sb.WriteBool(value)
+}
+
+// FieldString add string field (quoted).
+func (w *LineProtocolWriter) FieldString(key string, value string) {
+	w.beginField(key)
+	_ = w.sb.WriteByte('"')
+	w.sb.writeEscaped(value, lineProtocolEscapeString)
+	_ = w.sb.WriteByte('"')
+}
+
+// End ends the current point with timestamp ts (omitted if ts is zero) and new line.
+// Point without fields is removed and ErrLineProtocolNoFields returned.
+// Point with new line in measurement or field key is removed and ErrLineProtocolNewLine returned.
+func (w *LineProtocolWriter) End(ts time.Time) error {
+	if w.fields == 0 || w.newLine {
+		w.sb.Truncate(w.start)
+		w.tags = w.tags[:0]
+		if w.newLine {
+			w.newLine = false
+			w.fields = 0
+			return ErrLineProtocolNewLine
+		}
+		return ErrLineProtocolNoFields
+	}
+	if !ts.IsZero() {
+		_ = w.sb.WriteByte(' ')
+		w.sb.WriteInt(w.timestamp(ts), 10)
+	}
+	_ = w.sb.WriteByte('\n')
+	w.tags = w.tags[:0]
+	w.fields = 0
+	w.start = w.sb.Len()
+	return nil
+}
+
+// timestamp returns ts in precision units.
+func (w *LineProtocolWriter) timestamp(ts time.Time) int64 {
+	if p := int64(w.precision); int64(time.Second)%p == 0 {
+		// UnixNano overflows outside of years 1678-2262
+		return ts.Unix()*(int64(time.Second)/p) + int64(ts.Nanosecond())/p
+	}
+	return ts.UnixNano() / int64(w.precision)
+}
+
+// hasNewLine reports whether s contains new line or carriage return, which can't be escaped in line protocol names.
+func hasNewLine(s string) bool {
+	return strings.IndexByte(s, '\n') != -1 || strings.IndexByte(s, '\r') != -1
+}
+
+type lineProtocolEscape uint8
+
+const (
+	lineProtocolEscapeMeasurement lineProtocolEscape = iota // comma and space
+	lineProtocolEscapeKey                                   // comma, equals sign and space (tag keys, tag values and field keys)
+	lineProtocolEscapeString                                // double quote and backslash (field string values)
+)
+
+func (e lineProtocolEscape) need(c byte) bool {
+	switch e {
+	case lineProtocolEscapeMeasurement:
+		return c == ',' || c == ' '
+	case lineProtocolEscapeKey:
+		return c == ',' || c == '=' || c == ' '
+	default:
+		return c == '"' || c == '\\'
+	}
+}
+
+// writeEscaped appends s with special chars escaped by backslash.
+func (sb *Builder) writeEscaped(s string, e lineProtocolEscape) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; e.need(c) {
+			if start == 0 {
+				sb.reserve(len(s) + 4)
+			}
+			sb.WriteString(s[start:i])
+			_ = sb.WriteByte('\\')
+			_ = sb.WriteByte(c)
+			start = i + 1
+		}
+	}
+	sb.WriteString(s[start:])
+}
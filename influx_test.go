@@ -0,0 +1,144 @@
+package stringutils
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineProtocolWriter(t *testing.T) {
+	ts := time.Unix(1667464245, 123456789)
+
+	var sb Builder
+	w := NewLineProtocolWriter(&sb, time.Nanosecond)
+
+	w.Measurement("cpu")
+	w.Tag("region", "us-west")
+	w.Tag("host", "server01")
+	w.FieldFloat("value", 0.64)
+	w.FieldInt("count", -5)
+	w.FieldUint("total", 18446744073709551615)
+	w.FieldBool("ok", true)
+	w.FieldString("msg", `say "hi" \o/`)
+	assert.NoError(t, w.End(ts))
+
+	w.Measurement("my measurement,1")
+	w.Tag("tag key", "a=b,c d")
+	w.FieldFloat("field,key=", 1e21)
+	w.FieldFloat("nan", math.NaN())
+	assert.NoError(t, w.End(time.Time{}))
+
+	// point without fields is removed
+	w.Measurement("empty")
+	w.Tag("host", "a")
+	w.FieldFloat("inf", math.Inf(1))
+	assert.Equal(t, ErrLineProtocolNoFields, w.End(ts))
+
+	// empty tag value is skipped, tag after field is rejected
+	w.Measurement("mem")
+	assert.NoError(t, w.Tag("host", ""))
+	w.FieldInt("used", 1)
+	assert.Equal(t, ErrLineProtocolTagAfterField, w.Tag("region", "us-west"))
+	assert.NoError(t, w.End(ts))
+
+	// new line in names can't be escaped, so point is removed
+	assert.Equal(t, ErrLineProtocolNewLine, w.Tag("host", "a\nb"))
+	assert.Equal(t, ErrLineProtocolNewLine, w.Tag("ho\rst", "a"))
+	w.Measurement("cpu\nx")
+	w.FieldInt("v", 1)
+	assert.Equal(t, ErrLineProtocolNewLine, w.End(ts))
+	w.Measurement("cpu")
+	w.FieldInt("v", 1)
+	w.FieldString("f\nk", "v")
+	assert.Equal(t, ErrLineProtocolNewLine, w.End(ts))
+
+	// string field value can contain new line
+	w.Measurement("log")
+	w.FieldString("msg", "a\nb")
+	assert.NoError(t, w.End(time.Time{}))
+
+	assert.Equal(t,
+		"cpu,host=server01,region=us-west value=0.64,count=-5i,total=18446744073709551615u,ok=true,msg=\"say \\\"hi\\\" \\\\o/\" 1667464245123456789\n"+
+			"my\\ measurement\\,1,tag\\ key=a\\=b\\,c\\ d field\\,key\\==1e+21\n"+
+			"mem used=1i 1667464245123456789\n"+
+			"log msg=\"a\nb\"\n",
+		sb.String(),
+	)
+}
+
+func TestLineProtocolWriter_Precision(t *testing.T) {
+	ts := time.Unix(1667464245, 123456789)
+	tests := []struct {
+		precision time.Duration
+		want      string
+	}{
+		{0, "m v=1i 1667464245123456789\n"},
+		{time.Microsecond, "m v=1i 1667464245123456\n"},
+		{time.Millisecond, "m v=1i 1667464245123\n"},
+		{time.Second, "m v=1i 1667464245\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.precision.String(), func(t *testing.T) {
+			var sb Builder
+			w := NewLineProtocolWriter(&sb, tt.precision)
+			w.Measurement("m")
+			w.FieldInt("v", 1)
+			assert.NoError(t, w.End(ts))
+			assert.Equal(t, tt.want, sb.String())
+
+			sb.Reset()
+			w.Reset(&sb)
+			w.Measurement("m")
+			w.FieldInt("v", 1)
+			assert.NoError(t, w.End(ts))
+			assert.Equal(t, tt.want, w.Builder().String())
+		})
+	}
+}
+
+func TestLineProtocolWriter_TimestampOutOfRange(t *testing.T) {
+	// UnixNano overflows outside of years 1678-2262
+	tests := []struct {
+		ts        time.Time
+		precision time.Duration
+		want      string
+	}{
+		{time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC), time.Second, "m v=1i 16725225600\n"},
+		{time.Date(2500, 1, 1, 0, 0, 0, 1000000, time.UTC), time.Millisecond, "m v=1i 16725225600001\n"},
+		{time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), time.Microsecond, "m v=1i -11676096000000000\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ts.String()+" "+tt.precision.String(), func(t *testing.T) {
+			var sb Builder
+			w := NewLineProtocolWriter(&sb, tt.precision)
+			w.Measurement("m")
+			w.FieldInt("v", 1)
+			assert.NoError(t, w.End(tt.ts))
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func BenchmarkThis_LineProtocolWriter(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	w := NewLineProtocolWriter(&sb, time.Second)
+	ts := time.Unix(1667464245, 0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		w.Reset(&sb)
+		w.Measurement("cpu")
+		w.Tag("region", "us-west")
+		w.Tag("host", "server01")
+		w.Tag("dc", "dc1")
+		w.FieldFloat("value", 0.64)
+		w.FieldInt("count", 5)
+		_ = w.End(ts)
+	}
+}
@@ -0,0 +1,231 @@
+package stringutils
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// jsonSafe[c] is true if ASCII byte c can be written inside JSON string without escaping
+var jsonSafe = func() (t [utf8.RuneSelf]bool) {
+	for c := ' '; c < utf8.RuneSelf; c++ {
+		t[c] = c != '"' && c != '\\'
+	}
+	return
+}()
+
+// WriteJSONString appends the quoted JSON string form of s.
+// Control characters, U+2028, U+2029 are escaped, invalid UTF-8 sequences are replaced with U+FFFD.
+func (sb *Builder) WriteJSONString(s string) {
+	sb.reserve(len(s) + 2)
+	_ = sb.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if jsonSafe[c] {
+				i++
+				continue
+			}
+			sb.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				_ = sb.WriteByte('\\')
+				_ = sb.WriteByte(c)
+			case '\b':
+				sb.WriteString(`\b`)
+			case '\f':
+				sb.WriteString(`\f`)
+			case '\n':
+				sb.WriteString(`\n`)
+			case '\r':
+				sb.WriteString(`\r`)
+			case '\t':
+				sb.WriteString(`\t`)
+			default:
+				sb.WriteString(`\u00`)
+				_ = sb.WriteByte(hexDigits[c>>4])
+				_ = sb.WriteByte(hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			sb.WriteString(s[start:i])
+			sb.WriteString("\ufffd")
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 is LINE SEPARATOR, U+2029 is PARAGRAPH SEPARATOR.
+		// They are valid JSON, but not valid JavaScript string literals.
+		if r == '\u2028' || r == '\u2029' {
+			sb.WriteString(s[start:i])
+			sb.WriteString(`\u202`)
+			_ = sb.WriteByte(hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	sb.WriteString(s[start:])
+	_ = sb.WriteByte('"')
+}
+
+// WriteJSONFloat appends the JSON form of the floating-point number f (as encoding/json do).
+// NaN and Inf (not supported by JSON) are written as null.
+func (sb *Builder) WriteJSONFloat(f float64, bitSize int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		sb.WriteString("null")
+		return
+	}
+	// Convert as if by ES6 number to string conversion.
+	// This matches most other JSON generators.
+	abs := math.Abs(f)
+	fmt := byte('f')
+	if abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) || bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			fmt = 'e'
+		}
+	}
+	start := sb.Len()
+	sb.WriteFloat(f, fmt, -1, bitSize)
+	if fmt == 'e' {
+		// clean up e-09 to e-9
+		b := sb.data[start:]
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			sb.data = sb.data[:len(sb.data)-1]
+		}
+	}
+}
+
+// JSONWriter is a streaming JSON encoder over Builder (without reflection and intermediate allocations).
+// Commas between values are written automatically. The zero value is not usable, use NewJSONWriter or Reset.
+type JSONWriter struct {
+	sb    *Builder
+	comma bool
+	depth int
+}
+
+// NewJSONWriter return new JSONWriter, appended to sb.
+func NewJSONWriter(sb *Builder) *JSONWriter {
+	return &JSONWriter{sb: sb}
+}
+
+// Reset resets the JSONWriter state and set Builder for append.
+func (w *JSONWriter) Reset(sb *Builder) {
+	w.sb = sb
+	w.comma = false
+	w.depth = 0
+}
+
+// Builder return underlying Builder.
+func (w *JSONWriter) Builder() *Builder {
+	return w.sb
+}
+
+// Depth return current nesting level of objects and arrays.
+func (w *JSONWriter) Depth() int {
+	return w.depth
+}
+
+func (w *JSONWriter) sep() {
+	if w.comma {
+		_ = w.sb.WriteByte(',')
+	}
+}
+
+func (w *JSONWriter) begin(c byte) {
+	w.sep()
+	_ = w.sb.WriteByte(c)
+	w.comma = false
+	w.depth++
+}
+
+func (w *JSONWriter) end(c byte) {
+	_ = w.sb.WriteByte(c)
+	w.comma = true
+	w.depth--
+}
+
+// BeginObject writes object start '{'.
+func (w *JSONWriter) BeginObject() {
+	w.begin('{')
+}
+
+// EndObject writes object end '}'.
+func (w *JSONWriter) EndObject() {
+	w.end('}')
+}
+
+// BeginArray writes array start '['.
+func (w *JSONWriter) BeginArray() {
+	w.begin('[')
+}
+
+// EndArray writes array end ']'.
+func (w *JSONWriter) EndArray() {
+	w.end(']')
+}
+
+// Key writes object key and ':'. Next value written without comma.
+func (w *JSONWriter) Key(k string) {
+	w.sep()
+	w.sb.WriteJSONString(k)
+	_ = w.sb.WriteByte(':')
+	w.comma = false
+}
+
+// String writes string value.
+func (w *JSONWriter) String(s string) {
+	w.sep()
+	w.sb.WriteJSONString(s)
+	w.comma = true
+}
+
+// Int writes integer value.
+func (w *JSONWriter) Int(i int64) {
+	w.sep()
+	w.sb.WriteInt(i, 10)
+	w.comma = true
+}
+
+// Uint writes unsigned integer value.
+func (w *JSONWriter) Uint(u uint64) {
+	w.sep()
+	w.sb.WriteUint(u, 10)
+	w.comma = true
+}
+
+// Float writes floating-point value (NaN and Inf written as null).
+func (w *JSONWriter) Float(f float64) {
+	w.sep()
+	w.sb.WriteJSONFloat(f, 64)
+	w.comma = true
+}
+
+// Bool writes bool value.
+func (w *JSONWriter) Bool(v bool) {
+	w.sep()
+	w.sb.WriteBool(v)
+	w.comma = true
+}
+
+// Null writes null.
+func (w *JSONWriter) Null() {
+	w.sep()
+	w.sb.WriteString("null")
+	w.comma = true
+}
+
+// Raw writes pre-encoded JSON value as is (without validation).
+func (w *JSONWriter) Raw(v string) {
+	w.sep()
+	w.sb.WriteString(v)
+	w.comma = true
+}
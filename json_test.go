@@ -0,0 +1,136 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_WriteJSONString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", `""`},
+		{"hello", `"hello"`},
+		{"a\"b\\c/d", `"a\"b\\c/d"`},
+		{"\b\f\n\r\t", `"\b\f\n\r\t"`},
+		{"\x00\x01\x1f\x7f", `"\u0000\u0001\u001f` + "\x7f" + `"`},
+		{"line\u2028para\u2029", `"line\u2028para\u2029"`},
+		{"тест 世界", `"тест 世界"`},
+		{"bad\xffutf\xc3", `"bad` + "\ufffd" + `utf` + "\ufffd" + `"`},
+	}
+	for id, tt := range tests {
+		t.Run("Test #"+strconv.Itoa(id), func(t *testing.T) {
+			var sb Builder
+			sb.WriteJSONString(tt.s)
+			assert.Equal(t, tt.want, sb.String())
+			assert.True(t, json.Valid(sb.Bytes()), "invalid JSON")
+
+			var s string
+			assert.NoError(t, json.Unmarshal(sb.Bytes(), &s))
+			if want, err := json.Marshal(tt.s); err == nil {
+				var ws string
+				assert.NoError(t, json.Unmarshal(want, &ws))
+				assert.Equal(t, ws, s)
+			}
+		})
+	}
+}
+
+func TestBuilder_WriteJSONFloat(t *testing.T) {
+	tests := []float64{0, 1, -1.5, 1e20, 1e21, 1e-6, 1e-7, 123456.789, math.MaxFloat64, math.SmallestNonzeroFloat64}
+	for _, f := range tests {
+		t.Run(strconv.FormatFloat(f, 'g', -1, 64), func(t *testing.T) {
+			var sb Builder
+			sb.WriteJSONFloat(f, 64)
+			want, err := json.Marshal(f)
+			assert.NoError(t, err)
+			assert.Equal(t, string(want), sb.String())
+		})
+	}
+
+	var sb Builder
+	sb.WriteJSONFloat(math.NaN(), 64)
+	sb.WriteJSONFloat(math.Inf(-1), 64)
+	assert.Equal(t, "nullnull", sb.String())
+}
+
+func TestJSONWriter(t *testing.T) {
+	var sb Builder
+	w := NewJSONWriter(&sb)
+
+	w.BeginObject()
+	w.Key("name")
+	w.String("test\n")
+	w.Key("values")
+	w.BeginArray()
+	w.Int(-1)
+	w.Uint(2)
+	w.Float(3.5)
+	w.Float(math.NaN())
+	w.BeginObject()
+	w.EndObject()
+	w.BeginArray()
+	w.EndArray()
+	w.EndArray()
+	w.Key("ok")
+	w.Bool(true)
+	w.Key("nil")
+	w.Null()
+	w.Key("raw")
+	w.Raw(`{"a":1}`)
+	w.EndObject()
+
+	assert.Equal(t, 0, w.Depth())
+	assert.Equal(t, `{"name":"test\n","values":[-1,2,3.5,null,{},[]],"ok":true,"nil":null,"raw":{"a":1}}`, sb.String())
+	assert.True(t, json.Valid(sb.Bytes()))
+
+	sb.Reset()
+	w.Reset(&sb)
+	w.BeginArray()
+	w.String("a")
+	w.String("b")
+	w.EndArray()
+	assert.Equal(t, `["a","b"]`, sb.String())
+}
+
+func BenchmarkThis_JSONWriter(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	w := NewJSONWriter(&sb)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		w.Reset(&sb)
+		w.BeginObject()
+		w.Key("name")
+		w.String("test.metric\tname")
+		w.Key("value")
+		w.Float(12.5)
+		w.Key("ts")
+		w.Int(1667464245)
+		w.EndObject()
+	}
+}
+
+func BenchmarkStd_JSON_Marshal(b *testing.B) {
+	v := struct {
+		Name  string  `json:"name"`
+		Value float64 `json:"value"`
+		TS    int64   `json:"ts"`
+	}{"test.metric\tname", 12.5, 1667464245}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(v)
+	}
+}
@@ -0,0 +1,292 @@
+package stringutils
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrPrometheusLengthMismatch is returned when histogram or summary slices lengths are different
+	ErrPrometheusLengthMismatch = errors.New("stringutils: prometheus slices length mismatch")
+	// ErrPrometheusReservedLabel is returned when histogram or summary labels contain reserved le or quantile label
+	ErrPrometheusReservedLabel = errors.New("stringutils: prometheus reserved label")
+)
+
+// Prometheus metric types
+const (
+	PrometheusCounter   = "counter"
+	PrometheusGauge     = "gauge"
+	PrometheusHistogram = "histogram"
+	PrometheusSummary   = "summary"
+	PrometheusUntyped   = "untyped"
+)
+
+// PrometheusLabel is a metric label (name and value)
+type PrometheusLabel struct {
+	Name  string
+	Value string
+}
+
+func isPrometheusNameChar(c byte, first, colon bool) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') ||
+		(!first && '0' <= c && c <= '9') || (colon && c == ':')
+}
+
+func isValidPrometheusName(s string, colon bool) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isPrometheusNameChar(s[i], i == 0, colon) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidMetricName checks Prometheus metric name ([a-zA-Z_:][a-zA-Z0-9_:]*).
+func IsValidMetricName(s string) bool {
+	return isValidPrometheusName(s, true)
+}
+
+// IsValidLabelName checks Prometheus label name ([a-zA-Z_][a-zA-Z0-9_]*).
+func IsValidLabelName(s string) bool {
+	return isValidPrometheusName(s, false)
+}
+
+// writePrometheusName appends s with invalid chars replaced by '_' (also prefixed by '_' if started with digit).
+func (sb *Builder) writePrometheusName(s string, colon bool) {
+	if isValidPrometheusName(s, colon) {
+		sb.WriteString(s)
+		return
+	}
+	if len(s) == 0 {
+		_ = sb.WriteByte('_')
+		return
+	}
+	if '0' <= s[0] && s[0] <= '9' {
+		_ = sb.WriteByte('_')
+	}
+	pos := sb.Len()
+	sb.WriteString(s)
+	b := sb.data[pos:]
+	for i := 0; i < len(b); i++ {
+		if !isPrometheusNameChar(b[i], false, colon) {
+			b[i] = '_'
+		}
+	}
+}
+
+// WriteMetricName appends Prometheus metric name, invalid chars replaced by '_'.
+func (sb *Builder) WriteMetricName(s string) {
+	sb.writePrometheusName(s, true)
+}
+
+// SanitizeMetricName return Prometheus metric name with invalid chars replaced by '_' (s returned without allocation if valid).
+func SanitizeMetricName(s string) string {
+	if IsValidMetricName(s) {
+		return s
+	}
+	var sb Builder
+	sb.writePrometheusName(s, true)
+	return sb.String()
+}
+
+// SanitizeLabelName return Prometheus label name with invalid chars replaced by '_' (s returned without allocation if valid).
+func SanitizeLabelName(s string) string {
+	if IsValidLabelName(s) {
+		return s
+	}
+	var sb Builder
+	sb.writePrometheusName(s, false)
+	return sb.String()
+}
+
+// writePrometheusEscaped appends s with backslash and new line escaped (also double quote, if quote is true).
+func (sb *Builder) writePrometheusEscaped(s string, quote bool) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' && c != '\n' && !(quote && c == '"') {
+			continue
+		}
+		sb.WriteString(s[start:i])
+		_ = sb.WriteByte('\\')
+		if c == '\n' {
+			_ = sb.WriteByte('n')
+		} else {
+			_ = sb.WriteByte(c)
+		}
+		start = i + 1
+	}
+	sb.WriteString(s[start:])
+}
+
+// WritePrometheusFloat appends float in Prometheus text format (+Inf, -Inf and NaN for special values).
+func (sb *Builder) WritePrometheusFloat(f float64) {
+	switch {
+	case math.IsNaN(f):
+		sb.WriteString("NaN")
+	case math.IsInf(f, 1):
+		sb.WriteString("+Inf")
+	case math.IsInf(f, -1):
+		sb.WriteString("-Inf")
+	default:
+		sb.WriteFloat(f, 'g', -1, 64)
+	}
+}
+
+// PrometheusWriter is a Prometheus text exposition format encoder over Builder.
+// Metric and label names are sanitized, label values and help are escaped.
+type PrometheusWriter struct {
+	sb *Builder
+}
+
+// NewPrometheusWriter return new PrometheusWriter, appended to sb.
+func NewPrometheusWriter(sb *Builder) *PrometheusWriter {
+	return &PrometheusWriter{sb: sb}
+}
+
+// Reset set Builder for append.
+func (w *PrometheusWriter) Reset(sb *Builder) {
+	w.sb = sb
+}
+
+// Builder return underlying Builder.
+func (w *PrometheusWriter) Builder() *Builder {
+	return w.sb
+}
+
+// Help writes "# HELP name help" line.
+func (w *PrometheusWriter) Help(name, help string) {
+	w.sb.WriteString("# HELP ")
+	w.sb.WriteMetricName(name)
+	_ = w.sb.WriteByte(' ')
+	w.sb.writePrometheusEscaped(help, false)
+	_ = w.sb.WriteByte('\n')
+}
+
+// Type writes "# TYPE name type" line.
+func (w *PrometheusWriter) Type(name, typ string) {
+	w.sb.WriteString("# TYPE ")
+	w.sb.WriteMetricName(name)
+	_ = w.sb.WriteByte(' ')
+	w.sb.WriteString(typ)
+	_ = w.sb.WriteByte('\n')
+}
+
+// EOF writes "# EOF" line (end of OpenMetrics exposition).
+func (w *PrometheusWriter) EOF() {
+	w.sb.WriteString("# EOF\n")
+}
+
+// writeLabels writes labels with optional extra label (like le or quantile for histograms and summaries).
+func (w *PrometheusWriter) writeLabels(labels []PrometheusLabel, extraName string, extraValue float64) {
+	if len(labels) == 0 && extraName == "" {
+		return
+	}
+	_ = w.sb.WriteByte('{')
+	for i := range labels {
+		if i > 0 {
+			_ = w.sb.WriteByte(',')
+		}
+		w.sb.writePrometheusName(labels[i].Name, false)
+		w.sb.WriteString(`="`)
+		w.sb.writePrometheusEscaped(labels[i].Value, true)
+		_ = w.sb.WriteByte('"')
+	}
+	if extraName != "" {
+		if len(labels) > 0 {
+			_ = w.sb.WriteByte(',')
+		}
+		w.sb.WriteString(extraName)
+		w.sb.WriteString(`="`)
+		w.sb.WritePrometheusFloat(extraValue)
+		_ = w.sb.WriteByte('"')
+	}
+	_ = w.sb.WriteByte('}')
+}
+
+// hasLabel reports whether labels contain label with (sanitized) name.
+func hasLabel(labels []PrometheusLabel, name string) bool {
+	for i := range labels {
+		if SanitizeLabelName(labels[i].Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *PrometheusWriter) sample(name, suffix string, labels []PrometheusLabel, extraName string, extraValue float64) {
+	w.sb.WriteMetricName(name)
+	w.sb.WriteString(suffix)
+	w.writeLabels(labels, extraName, extraValue)
+	_ = w.sb.WriteByte(' ')
+}
+
+// Sample writes sample line "name{labels} value".
+func (w *PrometheusWriter) Sample(name string, labels []PrometheusLabel, value float64) {
+	w.sample(name, "", labels, "", 0)
+	w.sb.WritePrometheusFloat(value)
+	_ = w.sb.WriteByte('\n')
+}
+
+// SampleWithTimestamp writes sample line "name{labels} value timestamp" (timestamp in milliseconds since epoch).
+func (w *PrometheusWriter) SampleWithTimestamp(name string, labels []PrometheusLabel, value float64, tsMillis int64) {
+	w.sample(name, "", labels, "", 0)
+	w.sb.WritePrometheusFloat(value)
+	_ = w.sb.WriteByte(' ')
+	w.sb.WriteInt(tsMillis, 10)
+	_ = w.sb.WriteByte('\n')
+}
+
+func (w *PrometheusWriter) uintSample(name, suffix string, labels []PrometheusLabel, extraName string, extraValue float64, value uint64) {
+	w.sample(name, suffix, labels, extraName, extraValue)
+	w.sb.WriteUint(value, 10)
+	_ = w.sb.WriteByte('\n')
+}
+
+// Histogram writes histogram samples: name_bucket (for each upper bound with cumulative count and +Inf bucket), name_sum and name_count.
+// ErrPrometheusLengthMismatch is returned (and nothing written) if len(upperBounds) != len(cumulativeCounts),
+// ErrPrometheusReservedLabel if labels contain le label.
+func (w *PrometheusWriter) Histogram(name string, labels []PrometheusLabel, upperBounds []float64, cumulativeCounts []uint64, sum float64, count uint64) error {
+	if len(upperBounds) != len(cumulativeCounts) {
+		return ErrPrometheusLengthMismatch
+	}
+	if hasLabel(labels, "le") {
+		return ErrPrometheusReservedLabel
+	}
+	for i, le := range upperBounds {
+		w.uintSample(name, "_bucket", labels, "le", le, cumulativeCounts[i])
+	}
+	if len(upperBounds) == 0 || !math.IsInf(upperBounds[len(upperBounds)-1], 1) {
+		w.uintSample(name, "_bucket", labels, "le", math.Inf(1), count)
+	}
+	w.sample(name, "_sum", labels, "", 0)
+	w.sb.WritePrometheusFloat(sum)
+	_ = w.sb.WriteByte('\n')
+	w.uintSample(name, "_count", labels, "", 0, count)
+	return nil
+}
+
+// Summary writes summary samples: name{quantile} (for each quantile), name_sum and name_count.
+// ErrPrometheusLengthMismatch is returned (and nothing written) if len(quantiles) != len(values),
+// ErrPrometheusReservedLabel if labels contain quantile label.
+func (w *PrometheusWriter) Summary(name string, labels []PrometheusLabel, quantiles, values []float64, sum float64, count uint64) error {
+	if len(quantiles) != len(values) {
+		return ErrPrometheusLengthMismatch
+	}
+	if hasLabel(labels, "quantile") {
+		return ErrPrometheusReservedLabel
+	}
+	for i, q := range quantiles {
+		w.sample(name, "", labels, "quantile", q)
+		w.sb.WritePrometheusFloat(values[i])
+		_ = w.sb.WriteByte('\n')
+	}
+	w.sample(name, "_sum", labels, "", 0)
+	w.sb.WritePrometheusFloat(sum)
+	_ = w.sb.WriteByte('\n')
+	w.uintSample(name, "_count", labels, "", 0, count)
+	return nil
+}
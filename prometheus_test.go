@@ -0,0 +1,105 @@
+package stringutils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusNames(t *testing.T) {
+	tests := []struct {
+		s         string
+		metric    bool
+		label     bool
+		wantName  string
+		wantLabel string
+	}{
+		{"http_requests_total", true, true, "http_requests_total", "http_requests_total"},
+		{"job:rate5m", true, false, "job:rate5m", "job_rate5m"},
+		{"_a1", true, true, "_a1", "_a1"},
+		{"", false, false, "_", "_"},
+		{"1abc", false, false, "_1abc", "_1abc"},
+		{"a.b-c d", false, false, "a_b_c_d", "a_b_c_d"},
+		{"тест", false, false, "________", "________"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.metric, IsValidMetricName(tt.s))
+			assert.Equal(t, tt.label, IsValidLabelName(tt.s))
+			assert.Equal(t, tt.wantName, SanitizeMetricName(tt.s))
+			assert.Equal(t, tt.wantLabel, SanitizeLabelName(tt.s))
+		})
+	}
+}
+
+func TestPrometheusWriter(t *testing.T) {
+	var sb Builder
+	w := NewPrometheusWriter(&sb)
+
+	labels := []PrometheusLabel{{"method", "post"}, {"path", "/a\\b\"c\"\nd"}, {"bad-name", "1"}}
+
+	w.Help("http_requests_total", "Total requests.\nWith \\ escape.")
+	w.Type("http_requests_total", PrometheusCounter)
+	w.Sample("http_requests_total", labels, 1027)
+	w.SampleWithTimestamp("http_requests_total", labels[:1], 3, 1395066363000)
+	w.Sample("http.requests", nil, math.NaN())
+	w.Sample("inf", nil, math.Inf(-1))
+	w.Sample("small", nil, 1e-7)
+
+	w.Type("latency", PrometheusHistogram)
+	assert.NoError(t, w.Histogram("latency", labels[:1], []float64{0.05, 0.5, 1}, []uint64{24054, 33444, 100392}, 53423.5, 144320))
+	assert.NoError(t, w.Histogram("latency", nil, []float64{1, math.Inf(1)}, []uint64{1, 2}, 2.5, 2))
+
+	w.Type("rpc", PrometheusSummary)
+	assert.NoError(t, w.Summary("rpc", nil, []float64{0.5, 0.99}, []float64{4773, 76656}, 1.7560473e+07, 2693))
+	w.EOF()
+
+	assert.Equal(t, `# HELP http_requests_total Total requests.\nWith \\ escape.
+# TYPE http_requests_total counter
+http_requests_total{method="post",path="/a\\b\"c\"\nd",bad_name="1"} 1027
+http_requests_total{method="post"} 3 1395066363000
+http_requests NaN
+inf -Inf
+small 1e-07
+# TYPE latency histogram
+latency_bucket{method="post",le="0.05"} 24054
+latency_bucket{method="post",le="0.5"} 33444
+latency_bucket{method="post",le="1"} 100392
+latency_bucket{method="post",le="+Inf"} 144320
+latency_sum{method="post"} 53423.5
+latency_count{method="post"} 144320
+latency_bucket{le="1"} 1
+latency_bucket{le="+Inf"} 2
+latency_sum 2.5
+latency_count 2
+# TYPE rpc summary
+rpc{quantile="0.5"} 4773
+rpc{quantile="0.99"} 76656
+rpc_sum 1.7560473e+07
+rpc_count 2693
+# EOF
+`, sb.String())
+
+	n := sb.Len()
+	assert.Equal(t, ErrPrometheusLengthMismatch, w.Histogram("h", nil, []float64{1}, nil, 0, 0))
+	assert.Equal(t, ErrPrometheusLengthMismatch, w.Summary("s", nil, []float64{1}, nil, 0, 0))
+	assert.Equal(t, ErrPrometheusReservedLabel, w.Histogram("h", []PrometheusLabel{{"a", "1"}, {"le", "1"}}, []float64{1}, []uint64{1}, 0, 0))
+	assert.Equal(t, ErrPrometheusReservedLabel, w.Summary("s", []PrometheusLabel{{"quantile", "1"}}, []float64{1}, []float64{1}, 0, 0))
+	assert.Equal(t, n, sb.Len())
+}
+
+func BenchmarkThis_PrometheusWriter(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	w := NewPrometheusWriter(&sb)
+	labels := []PrometheusLabel{{"method", "post"}, {"code", "200"}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		w.Sample("http_requests_total", labels, 1027)
+	}
+}
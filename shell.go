@@ -0,0 +1,172 @@
+package stringutils
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrShellQuote is returned when shell words string has unterminated single or double quote
+	ErrShellQuote = errors.New("stringutils: unterminated quote")
+	// ErrShellEscape is returned when shell words string ends with unterminated backslash escape
+	ErrShellEscape = errors.New("stringutils: unterminated backslash escape")
+)
+
+// shellSafe[c] is true if byte c can be used in shell word without quoting
+var shellSafe = func() (t [256]bool) {
+	for c := 'a'; c <= 'z'; c++ {
+		t[c] = true
+	}
+	for c := 'A'; c <= 'Z'; c++ {
+		t[c] = true
+	}
+	for c := '0'; c <= '9'; c++ {
+		t[c] = true
+	}
+	for _, c := range "_@%+=:,./-" {
+		t[c] = true
+	}
+	return
+}()
+
+func needShellQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		if !shellSafe[s[i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteShellQuoted appends s, quoted for POSIX shell (only when necessary).
+// Quoted string is enclosed in single quotes, embedded single quote is written as quote end, escaped quote and quote start.
+func (sb *Builder) WriteShellQuoted(s string) {
+	if !needShellQuote(s) {
+		sb.WriteString(s)
+		return
+	}
+	sb.reserve(len(s) + 2)
+	_ = sb.WriteByte('\'')
+	for {
+		i := strings.IndexByte(s, '\'')
+		if i == -1 {
+			break
+		}
+		sb.WriteString(s[:i])
+		sb.WriteString(`'\''`)
+		s = s[i+1:]
+	}
+	sb.WriteString(s)
+	_ = sb.WriteByte('\'')
+}
+
+// ShellQuote returns s, quoted for POSIX shell (only when necessary, so s without special characters returned without allocation).
+func ShellQuote(s string) string {
+	if !needShellQuote(s) {
+		return s
+	}
+	var sb Builder
+	sb.Grow(len(s) + 2 + 2*strings.Count(s, "'"))
+	sb.WriteShellQuoted(s)
+	return sb.String()
+}
+
+func isShellSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// SplitShellWords split s into words like POSIX shell (use pre-allocated buffer) (realloc if needed).
+// Words are separated by unquoted whitespace, single quotes, double quotes and backslash escapes are handled
+// (variables, globs and command substitutions are not expanded).
+// Words without quotes and escapes are substrings of s, all unquoted words share single allocated buffer.
+func SplitShellWords(s string, buf []string) ([]string, error) {
+	buf = buf[:0]
+	var sb Builder
+	i := 0
+	for {
+		for i < len(s) && isShellSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return buf, nil
+		}
+
+		// plain word
+		start := i
+		for i < len(s) && !isShellSpace(s[i]) && s[i] != '\\' && s[i] != '\'' && s[i] != '"' {
+			i++
+		}
+		if i == len(s) || isShellSpace(s[i]) {
+			buf = append(buf, s[start:i])
+			continue
+		}
+
+		// word with quotes or escapes, unquoted word never longer than s, so sb never reallocated
+		if sb.Cap() == 0 {
+			sb.Grow(len(s))
+		}
+		wordStart := sb.Len()
+		quoted := false
+		sb.WriteString(s[start:i])
+	word:
+		for i < len(s) {
+			switch c := s[i]; c {
+			case ' ', '\t', '\n':
+				break word
+			case '\\':
+				i++
+				if i == len(s) {
+					return buf, ErrShellEscape
+				}
+				// backslash-newline is a line continuation
+				if s[i] != '\n' {
+					_ = sb.WriteByte(s[i])
+				}
+				i++
+			case '\'':
+				quoted = true
+				end := strings.IndexByte(s[i+1:], '\'')
+				if end == -1 {
+					return buf, ErrShellQuote
+				}
+				sb.WriteString(s[i+1 : i+1+end])
+				i += end + 2
+			case '"':
+				quoted = true
+				i++
+				for {
+					if i == len(s) {
+						return buf, ErrShellQuote
+					}
+					c = s[i]
+					if c == '"' {
+						i++
+						break
+					}
+					if c == '\\' && i+1 < len(s) {
+						switch next := s[i+1]; next {
+						case '$', '`', '"', '\\':
+							_ = sb.WriteByte(next)
+							i += 2
+							continue
+						case '\n':
+							i += 2
+							continue
+						}
+					}
+					_ = sb.WriteByte(c)
+					i++
+				}
+			default:
+				_ = sb.WriteByte(c)
+				i++
+			}
+		}
+		if sb.Len() > wordStart || quoted {
+			buf = append(buf, UnsafeString(sb.data[wordStart:]))
+		}
+	}
+}
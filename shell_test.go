@@ -0,0 +1,98 @@
+package stringutils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", "''"},
+		{"plain", "plain"},
+		{"/usr/bin/env", "/usr/bin/env"},
+		{"--key=a,b:c@d+1%", "--key=a,b:c@d+1%"},
+		{"two words", "'two words'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+		{"a\"b", `'a"b'`},
+		{"a\\b", `'a\b'`},
+		{"*.go", "'*.go'"},
+		{"new\nline", "'new\nline'"},
+		{"тест", "'тест'"},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.s), func(t *testing.T) {
+			assert.Equal(t, tt.want, ShellQuote(tt.s))
+
+			var sb Builder
+			sb.WriteShellQuoted(tt.s)
+			assert.Equal(t, tt.want, sb.String())
+
+			words, err := SplitShellWords(tt.want, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.s}, words, "round trip")
+		})
+	}
+}
+
+func TestSplitShellWords(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    []string
+		wantErr error
+	}{
+		{s: "", want: nil},
+		{s: " \t\n", want: nil},
+		{s: "ls -la /tmp", want: []string{"ls", "-la", "/tmp"}},
+		{s: "  a   b  ", want: []string{"a", "b"}},
+		{s: `echo 'single quoted' "double quoted"`, want: []string{"echo", "single quoted", "double quoted"}},
+		{s: `a'b'"c"d`, want: []string{"abcd"}},
+		{s: `'' ""`, want: []string{"", ""}},
+		{s: `one\ word \'q\' \\`, want: []string{"one word", "'q'", `\`}},
+		{s: `'no \escape "here'`, want: []string{`no \escape "here`}},
+		{s: `"\$HOME \"q\" \\ \n"`, want: []string{`$HOME "q" \ \n`}},
+		{s: "line\\\ncontinue", want: []string{"linecontinue"}},
+		{s: "\"multi\nline\"", want: []string{"multi\nline"}},
+		{s: "тест 'мир'", want: []string{"тест", "мир"}},
+		{s: "a 'unterminated", want: []string{"a"}, wantErr: ErrShellQuote},
+		{s: `a "unterminated\"`, want: []string{"a"}, wantErr: ErrShellQuote},
+		{s: `a b\`, want: []string{"a"}, wantErr: ErrShellEscape},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.s), func(t *testing.T) {
+			got, err := SplitShellWords(tt.s, nil)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitShellWords_Allocs(t *testing.T) {
+	buf := make([]string, 0, 8)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = SplitShellWords("ls -la /tmp", buf)
+	})
+	assert.Equal(t, 0.0, allocs)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		buf, _ = SplitShellWords(`grep -e 'a b' "c d" e\ f`, buf)
+	})
+	assert.Equal(t, 1.0, allocs)
+}
+
+func BenchmarkThis_SplitShellWords(b *testing.B) {
+	s := `clickhouse-client --query 'SELECT 1' --format "TSV" --host localhost`
+	buf := make([]string, 0, 8)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf, _ = SplitShellWords(s, buf)
+	}
+}
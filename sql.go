@@ -0,0 +1,173 @@
+package stringutils
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// SQLDialect is a SQL dialect for string literals and identifiers quoting
+type SQLDialect uint8
+
+const (
+	// ClickHouse dialect: 'string' with backslash escapes (arbitrary bytes are preserved), `identifier`
+	ClickHouse SQLDialect = iota
+	// PostgreSQL dialect (with standard_conforming_strings=on): 'string' with doubled quotes, "identifier".
+	// NUL bytes and invalid UTF-8 sequences are not allowed in PostgreSQL text, so replaced with U+FFFD.
+	PostgreSQL
+	// MySQL dialect: 'string' with backslash escapes, `identifier`.
+	// Invalid UTF-8 sequences are replaced with U+FFFD.
+	MySQL
+)
+
+var sqlDialectStrings = [...]string{"ClickHouse", "PostgreSQL", "MySQL"}
+
+func (d SQLDialect) String() string {
+	if int(d) < len(sqlDialectStrings) {
+		return sqlDialectStrings[d]
+	}
+	return "SQLDialect(" + strconv.Itoa(int(d)) + ")"
+}
+
+// sqlEscape return escaped form of ASCII byte c inside string literal (or quoted identifier, if ident) or empty string.
+func (d SQLDialect) sqlEscape(c byte, ident bool) string {
+	switch d {
+	case PostgreSQL:
+		switch c {
+		case 0:
+			return "\uFFFD"
+		case '\'':
+			if !ident {
+				return "''"
+			}
+		case '"':
+			if ident {
+				return `""`
+			}
+		}
+	case MySQL:
+		if ident {
+			switch c {
+			case 0:
+				return "\uFFFD"
+			case '`':
+				return "``"
+			}
+			return ""
+		}
+		switch c {
+		case 0:
+			return `\0`
+		case '\n':
+			return `\n`
+		case '\r':
+			return `\r`
+		case '\\':
+			return `\\`
+		case '\'':
+			return `\'`
+		case '"':
+			return `\"`
+		case 0x1a:
+			return `\Z`
+		}
+	default:
+		switch c {
+		case 0:
+			return `\0`
+		case '\b':
+			return `\b`
+		case '\f':
+			return `\f`
+		case '\n':
+			return `\n`
+		case '\r':
+			return `\r`
+		case '\t':
+			return `\t`
+		case '\\':
+			return `\\`
+		case '\'':
+			if !ident {
+				return `\'`
+			}
+		case '`':
+			if ident {
+				return "\\`"
+			}
+		}
+	}
+	return ""
+}
+
+// writeSQLEscaped appends s, escaped for string literal (or quoted identifier, if ident) in dialect d.
+// If like, LIKE pattern special characters (%, _ and \) are escaped with backslash.
+func (sb *Builder) writeSQLEscaped(s string, d SQLDialect, ident, like bool) {
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				sb.WriteString(s[start:i])
+				if d == ClickHouse {
+					sb.WriteString(`\x`)
+					_ = sb.WriteByte(hexDigitsUpper[c>>4])
+					_ = sb.WriteByte(hexDigitsUpper[c&0xF])
+				} else {
+					sb.WriteString("\uFFFD")
+				}
+				start = i + 1
+			}
+			i += size
+			continue
+		}
+		if like && (c == '%' || c == '_' || c == '\\') {
+			sb.WriteString(s[start:i])
+			if e := d.sqlEscape('\\', ident); e != "" {
+				sb.WriteString(e)
+			} else {
+				_ = sb.WriteByte('\\')
+			}
+			start = i
+		}
+		if e := d.sqlEscape(c, ident); e != "" {
+			sb.WriteString(s[start:i])
+			sb.WriteString(e)
+			start = i + 1
+		}
+		i++
+	}
+	sb.WriteString(s[start:])
+}
+
+// WriteSQLString appends s as quoted SQL string literal for dialect d (like 'it\'s' for ClickHouse or 'it”s' for PostgreSQL).
+func (sb *Builder) WriteSQLString(s string, d SQLDialect) {
+	sb.reserve(len(s) + 2)
+	_ = sb.WriteByte('\'')
+	sb.writeSQLEscaped(s, d, false, false)
+	_ = sb.WriteByte('\'')
+}
+
+// WriteSQLIdentifier appends name as quoted SQL identifier for dialect d (like `name` for ClickHouse and MySQL or "name" for PostgreSQL).
+func (sb *Builder) WriteSQLIdentifier(name string, d SQLDialect) {
+	q := byte('`')
+	if d == PostgreSQL {
+		q = '"'
+	}
+	sb.reserve(len(name) + 2)
+	_ = sb.WriteByte(q)
+	sb.writeSQLEscaped(name, d, true, false)
+	_ = sb.WriteByte(q)
+}
+
+// WriteSQLLike appends s as quoted SQL string literal for LIKE pattern, matched s literally, for dialect d.
+// LIKE special characters (%, _ and \) are escaped with backslash (default LIKE escape character).
+// Wildcards can be appended around it with prefix and suffix (like "%").
+func (sb *Builder) WriteSQLLike(prefix, s, suffix string, d SQLDialect) {
+	sb.reserve(len(prefix) + len(s) + len(suffix) + 2)
+	_ = sb.WriteByte('\'')
+	sb.writeSQLEscaped(prefix, d, false, false)
+	sb.writeSQLEscaped(s, d, false, true)
+	sb.writeSQLEscaped(suffix, d, false, false)
+	_ = sb.WriteByte('\'')
+}
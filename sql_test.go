@@ -0,0 +1,126 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_WriteSQLString(t *testing.T) {
+	tests := []struct {
+		s          string
+		clickhouse string
+		postgresql string
+		mysql      string
+	}{
+		{s: "", clickhouse: `''`, postgresql: `''`, mysql: `''`},
+		{s: "plain", clickhouse: `'plain'`, postgresql: `'plain'`, mysql: `'plain'`},
+		{s: "it's", clickhouse: `'it\'s'`, postgresql: `'it''s'`, mysql: `'it\'s'`},
+		{s: `a\b`, clickhouse: `'a\\b'`, postgresql: `'a\b'`, mysql: `'a\\b'`},
+		{s: `\'`, clickhouse: `'\\\''`, postgresql: `'\'''`, mysql: `'\\\''`},
+		{s: `"q"`, clickhouse: `'"q"'`, postgresql: `'"q"'`, mysql: `'\"q\"'`},
+		{s: "nul\x00byte", clickhouse: `'nul\0byte'`, postgresql: "'nul\uFFFDbyte'", mysql: `'nul\0byte'`},
+		{s: "tab\tnl\ncr\r", clickhouse: `'tab\tnl\ncr\r'`, postgresql: "'tab\tnl\ncr\r'", mysql: "'tab\tnl\\ncr\\r'"},
+		{s: "ctrl-z\x1a", clickhouse: "'ctrl-z\x1a'", postgresql: "'ctrl-z\x1a'", mysql: `'ctrl-z\Z'`},
+		{s: "тест", clickhouse: `'тест'`, postgresql: `'тест'`, mysql: `'тест'`},
+		{s: "bad\xff\xc3utf", clickhouse: `'bad\xFF\xC3utf'`, postgresql: "'bad\uFFFD\uFFFDutf'", mysql: "'bad\uFFFD\uFFFDutf'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.clickhouse, func(t *testing.T) {
+			var sb Builder
+			sb.WriteSQLString(tt.s, ClickHouse)
+			assert.Equal(t, tt.clickhouse, sb.String(), "ClickHouse")
+
+			sb.Reset()
+			sb.WriteSQLString(tt.s, PostgreSQL)
+			assert.Equal(t, tt.postgresql, sb.String(), "PostgreSQL")
+
+			sb.Reset()
+			sb.WriteSQLString(tt.s, MySQL)
+			assert.Equal(t, tt.mysql, sb.String(), "MySQL")
+		})
+	}
+}
+
+func TestBuilder_WriteSQLIdentifier(t *testing.T) {
+	tests := []struct {
+		s          string
+		clickhouse string
+		postgresql string
+		mysql      string
+	}{
+		{s: "table", clickhouse: "`table`", postgresql: `"table"`, mysql: "`table`"},
+		{s: "my`tab\"le", clickhouse: "`my\\`tab\"le`", postgresql: `"my` + "`" + `tab""le"`, mysql: "`my``tab\"le`"},
+		{s: `a\b'`, clickhouse: "`a\\\\b'`", postgresql: `"a\b'"`, mysql: "`a\\b'`"},
+		{s: "nul\x00", clickhouse: "`nul\\0`", postgresql: "\"nul\uFFFD\"", mysql: "`nul\uFFFD`"},
+		{s: "bad\xff", clickhouse: "`bad\\xFF`", postgresql: "\"bad\uFFFD\"", mysql: "`bad\uFFFD`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.clickhouse, func(t *testing.T) {
+			var sb Builder
+			sb.WriteSQLIdentifier(tt.s, ClickHouse)
+			assert.Equal(t, tt.clickhouse, sb.String(), "ClickHouse")
+
+			sb.Reset()
+			sb.WriteSQLIdentifier(tt.s, PostgreSQL)
+			assert.Equal(t, tt.postgresql, sb.String(), "PostgreSQL")
+
+			sb.Reset()
+			sb.WriteSQLIdentifier(tt.s, MySQL)
+			assert.Equal(t, tt.mysql, sb.String(), "MySQL")
+		})
+	}
+}
+
+func TestBuilder_WriteSQLLike(t *testing.T) {
+	tests := []struct {
+		prefix, s, suffix string
+		clickhouse        string
+		postgresql        string
+		mysql             string
+	}{
+		{s: "plain", clickhouse: `'plain'`, postgresql: `'plain'`, mysql: `'plain'`},
+		{prefix: "%", s: "100%_done", suffix: "%", clickhouse: `'%100\\%\\_done%'`, postgresql: `'%100\%\_done%'`, mysql: `'%100\\%\\_done%'`},
+		{s: `a\b`, suffix: "_", clickhouse: `'a\\\\b_'`, postgresql: `'a\\b_'`, mysql: `'a\\\\b_'`},
+		{s: "it's%", clickhouse: `'it\'s\\%'`, postgresql: `'it''s\%'`, mysql: `'it\'s\\%'`},
+		{s: "nul\x00", clickhouse: `'nul\0'`, postgresql: "'nul\uFFFD'", mysql: `'nul\0'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.clickhouse, func(t *testing.T) {
+			var sb Builder
+			sb.WriteSQLLike(tt.prefix, tt.s, tt.suffix, ClickHouse)
+			assert.Equal(t, tt.clickhouse, sb.String(), "ClickHouse")
+
+			sb.Reset()
+			sb.WriteSQLLike(tt.prefix, tt.s, tt.suffix, PostgreSQL)
+			assert.Equal(t, tt.postgresql, sb.String(), "PostgreSQL")
+
+			sb.Reset()
+			sb.WriteSQLLike(tt.prefix, tt.s, tt.suffix, MySQL)
+			assert.Equal(t, tt.mysql, sb.String(), "MySQL")
+		})
+	}
+}
+
+func TestSQLDialect_String(t *testing.T) {
+	assert.Equal(t, "ClickHouse", ClickHouse.String())
+	assert.Equal(t, "PostgreSQL", PostgreSQL.String())
+	assert.Equal(t, "MySQL", MySQL.String())
+	assert.Equal(t, "SQLDialect(10)", SQLDialect(10).String())
+}
+
+func BenchmarkThis_WriteSQLString(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteString("SELECT Path FROM graphite_index WHERE Path LIKE ")
+		sb.WriteSQLLike("", "carbon.agents_1.", "%", ClickHouse)
+		sb.WriteString(" AND Date = ")
+		sb.WriteSQLString("1970-02-12", ClickHouse)
+	}
+}
@@ -1,9 +1,31 @@
 package stringutils
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"unicode/utf8"
 )
 
+var (
+	_ io.Writer       = (*Builder)(nil)
+	_ io.ByteWriter   = (*Builder)(nil)
+	_ io.StringWriter = (*Builder)(nil)
+	_ io.ReaderFrom   = (*Builder)(nil)
+	_ io.WriterTo     = (*Builder)(nil)
+)
+
+var (
+	// ErrOutOfRange is returned when position is outside of the Builder accumulated bytes
+	ErrOutOfRange = errors.New("stringutils.Builder: position out of range")
+	// ErrNotRuneBoundary is returned when position is not on UTF-8 rune boundary
+	ErrNotRuneBoundary = errors.New("stringutils.Builder: position is not on rune boundary")
+	// ErrBuilderFull is returned when write exceed the BoundedBuilder limit
+	ErrBuilderFull = errors.New("stringutils.Builder: limit exceeded")
+
+	errNegativeRead = errors.New("stringutils.Builder: reader returned negative count from Read")
+)
+
 // A Builder is used to efficiently build a string using Write methods (with better perfomance than strings.Builder).
 // It minimizes memory copying. The zero value is ready to use.
 // Do not copy a non-zero Builder.
@@ -14,6 +36,9 @@ type Builder struct {
 // grow scale factor for needed resize
 const scaleFactor = 2
 
+// minRead is the minimum slice size passed to a Read call by Builder.ReadFrom
+const minRead = 512
+
 // Len returns the number of accumulated bytes; b.Len() == len(b.String()).
 func (sb *Builder) Len() int {
 	return len(sb.data)
@@ -51,6 +76,27 @@ func (sb *Builder) Grow(capacity int) {
 	}
 }
 
+// reserve guarantee space for another n bytes (reallocate with scale factor, if needed).
+func (sb *Builder) reserve(n int) {
+	length := len(sb.data)
+	if length+n > cap(sb.data) {
+		capacity := length * scaleFactor
+		if capacity < length+n {
+			capacity = length + n
+		}
+		sb.Grow(capacity)
+	}
+}
+
+// extend increase the Builder length by n bytes (reallocate with scale factor, if needed) and return previous length.
+// Appended bytes are not initialized, caller must fill them.
+func (sb *Builder) extend(n int) int {
+	sb.reserve(n)
+	length := len(sb.data)
+	sb.data = sb.data[:length+n]
+	return length
+}
+
 // Reset resets the Builder to be empty.
 func (sb *Builder) Reset() {
 	if len(sb.data) > 0 {
@@ -65,6 +111,30 @@ func (sb *Builder) Truncate(length int) {
 	}
 }
 
+// TruncateUTF8 descrease the Builder length to length or less, but never cuts in the middle of UTF-8 rune
+// (partial rune at the end is removed).
+func (sb *Builder) TruncateUTF8(length int) {
+	if length < 0 {
+		length = 0
+	}
+	if len(sb.data) <= length {
+		return
+	}
+	// skip back to rune start (limited by UTFMax for invalid sequences)
+	for i := length; i >= 0 && i > length-utf8.UTFMax; i-- {
+		if utf8.RuneStart(sb.data[i]) {
+			if i < length {
+				if r, size := utf8.DecodeRune(sb.data[i:]); (r != utf8.RuneError || size > 1) && i+size > length {
+					// valid rune, cutted by length
+					length = i
+				}
+			}
+			break
+		}
+	}
+	sb.data = sb.data[:length]
+}
+
 // Release resets the Builder to be empty and free buffer
 func (sb *Builder) Release() {
 	if cap(sb.data) > 0 {
@@ -143,3 +213,47 @@ func (sb *Builder) WriteRune(r rune) (int, error) {
 func (sb *Builder) Flush() error {
 	return nil
 }
+
+// ReadFrom reads data from r until EOF and appends it to the buffer (directly into spare capacity, growing the buffer as needed).
+// The return value n is the number of bytes read. Any error except io.EOF encountered during the read is also returned.
+func (sb *Builder) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		sb.reserve(minRead)
+		length := len(sb.data)
+		m, e := r.Read(sb.data[length:cap(sb.data)])
+		if m < 0 {
+			panic(errNegativeRead)
+		}
+		sb.data = sb.data[:length+m]
+		n += int64(m)
+		if e == io.EOF {
+			return n, nil
+		}
+		if e != nil {
+			return n, e
+		}
+	}
+}
+
+// WriteTo writes accumulated data to w. Unlike bytes.Buffer, the Builder is not drained.
+// The return value n is the number of bytes written. Any error encountered during the write is also returned.
+func (sb *Builder) WriteTo(w io.Writer) (n int64, err error) {
+	if len(sb.data) == 0 {
+		return 0, nil
+	}
+	m, err := w.Write(sb.data)
+	if m > len(sb.data) {
+		panic("stringutils.Builder.WriteTo: invalid Write count")
+	}
+	n = int64(m)
+	if err == nil && m != len(sb.data) {
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
+
+// NewReader returns a new reader over the accumulated bytes (without copy).
+// Reader must not be used after Builder Reset, Truncate or Release.
+func (sb *Builder) NewReader() *bytes.Reader {
+	return bytes.NewReader(sb.data)
+}
@@ -0,0 +1,237 @@
+package stringutils
+
+import (
+	"io"
+	"time"
+)
+
+// A BoundedBuilder is a Builder with hard limit of length (for protocol framing, like UDP packets).
+// Every Write method either write the whole value, or nothing and return ErrBuilderFull,
+// so caller can flush and retry the last record.
+// Error is sticky: after ErrBuilderFull all writes fail until Reset or Truncate.
+type BoundedBuilder struct {
+	sb    Builder
+	limit int
+	err   error
+}
+
+// NewBoundedBuilder return new BoundedBuilder with preallocated buffer of limit size.
+func NewBoundedBuilder(limit int) *BoundedBuilder {
+	b := &BoundedBuilder{limit: limit}
+	b.sb.Grow(limit)
+	return b
+}
+
+// check rollback the last write (restore saved buffer), if limit exceeded.
+func (b *BoundedBuilder) check(data []byte) error {
+	if len(b.sb.data) > b.limit {
+		b.sb.data = data
+		b.err = ErrBuilderFull
+		return b.err
+	}
+	return nil
+}
+
+// Limit returns the maximum length.
+func (b *BoundedBuilder) Limit() int {
+	return b.limit
+}
+
+// Len returns the number of accumulated bytes.
+func (b *BoundedBuilder) Len() int {
+	return len(b.sb.data)
+}
+
+// Available returns how many bytes can be written before limit.
+func (b *BoundedBuilder) Available() int {
+	return b.limit - len(b.sb.data)
+}
+
+// Err returns ErrBuilderFull, if some write exceed the limit after last Reset or Truncate.
+func (b *BoundedBuilder) Err() error {
+	return b.err
+}
+
+// Bytes returns the accumulated bytes.
+func (b *BoundedBuilder) Bytes() []byte {
+	return b.sb.Bytes()
+}
+
+// String returns the accumulated string.
+func (b *BoundedBuilder) String() string {
+	return b.sb.String()
+}
+
+// Reset resets the BoundedBuilder to be empty and clear error.
+func (b *BoundedBuilder) Reset() {
+	b.sb.Reset()
+	b.err = nil
+}
+
+// Truncate descrease the BoundedBuilder length and clear error.
+func (b *BoundedBuilder) Truncate(length int) {
+	b.sb.Truncate(length)
+	b.err = nil
+}
+
+// WriteTo writes accumulated data to w.
+func (b *BoundedBuilder) WriteTo(w io.Writer) (int64, error) {
+	return b.sb.WriteTo(w)
+}
+
+// Append calls f for write the whole record into underlying Builder.
+// If limit exceeded, record is rolled back and ErrBuilderFull returned.
+func (b *BoundedBuilder) Append(f func(sb *Builder)) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	f(&b.sb)
+	return b.check(data)
+}
+
+// Write like WriteBytes, but realized io.Writer interface
+func (b *BoundedBuilder) Write(bytes []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if len(b.sb.data)+len(bytes) > b.limit {
+		b.err = ErrBuilderFull
+		return 0, b.err
+	}
+	return b.sb.Write(bytes)
+}
+
+// WriteBytes appends the contents of p to b's buffer.
+func (b *BoundedBuilder) WriteBytes(bytes []byte) error {
+	_, err := b.Write(bytes)
+	return err
+}
+
+// WriteString appends the contents of s to b's buffer.
+func (b *BoundedBuilder) WriteString(s string) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if len(b.sb.data)+len(s) > b.limit {
+		b.err = ErrBuilderFull
+		return 0, b.err
+	}
+	return b.sb.WriteString(s)
+}
+
+// WriteByte appends the byte c to b's buffer.
+func (b *BoundedBuilder) WriteByte(c byte) error {
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.sb.data) >= b.limit {
+		b.err = ErrBuilderFull
+		return b.err
+	}
+	return b.sb.WriteByte(c)
+}
+
+// WriteRune appends the UTF-8 encoding of Unicode code point r to b's buffer.
+func (b *BoundedBuilder) WriteRune(r rune) (n int, err error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	data := b.sb.data
+	n, _ = b.sb.WriteRune(r)
+	if err = b.check(data); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// WriteInt appends the string form of the integer i, as generated by FormatInt.
+func (b *BoundedBuilder) WriteInt(i int64, base int) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteInt(i, base)
+	return b.check(data)
+}
+
+// WriteUint appends the string form of the unsigned integer i, as generated by FormatUint.
+func (b *BoundedBuilder) WriteUint(i uint64, base int) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteUint(i, base)
+	return b.check(data)
+}
+
+// WriteFloat appends the string form of the floating-point number f,
+// as generated by FormatFloat.
+func (b *BoundedBuilder) WriteFloat(f float64, fmt byte, prec, bitSize int) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteFloat(f, fmt, prec, bitSize)
+	return b.check(data)
+}
+
+// WriteBool appends the string form of the bool v, as generated by FormatBool.
+func (b *BoundedBuilder) WriteBool(v bool) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteBool(v)
+	return b.check(data)
+}
+
+// WriteQuote appends the string form of the quoted string s, as generated by Quote.
+func (b *BoundedBuilder) WriteQuote(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteQuote(s)
+	return b.check(data)
+}
+
+// WriteTime appends the textual representation of t, like Builder.WriteTime.
+func (b *BoundedBuilder) WriteTime(t time.Time, layout string) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteTime(t, layout)
+	return b.check(data)
+}
+
+// WriteDuration appends the string form of the duration d, like Builder.WriteDuration.
+func (b *BoundedBuilder) WriteDuration(d time.Duration) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteDuration(d)
+	return b.check(data)
+}
+
+// WriteJSONString appends the quoted JSON string form of s, like Builder.WriteJSONString.
+func (b *BoundedBuilder) WriteJSONString(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.WriteJSONString(s)
+	return b.check(data)
+}
+
+// Printf appends formatted string, like Builder.Printf.
+func (b *BoundedBuilder) Printf(format string, args ...interface{}) error {
+	if b.err != nil {
+		return b.err
+	}
+	data := b.sb.data
+	b.sb.Printf(format, args...)
+	return b.check(data)
+}
@@ -0,0 +1,95 @@
+package stringutils
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedBuilder(t *testing.T) {
+	b := NewBoundedBuilder(16)
+	assert.Equal(t, 16, b.Limit())
+	assert.Equal(t, 16, b.Available())
+
+	n, err := b.WriteString("a.b.c ")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.NoError(t, b.WriteInt(12345, 10))
+	assert.NoError(t, b.WriteByte(' '))
+	assert.Equal(t, "a.b.c 12345 ", b.String())
+	assert.Equal(t, 4, b.Available())
+
+	// value not fit, nothing written
+	assert.Equal(t, ErrBuilderFull, b.WriteInt(1667464245, 10))
+	assert.Equal(t, "a.b.c 12345 ", b.String())
+	assert.Equal(t, ErrBuilderFull, b.Err())
+
+	// sticky error
+	assert.Equal(t, ErrBuilderFull, b.WriteByte('1'))
+	n, err = b.Write([]byte("1"))
+	assert.Equal(t, 0, n)
+	assert.Equal(t, ErrBuilderFull, err)
+	assert.Equal(t, "a.b.c 12345 ", b.String())
+
+	// flush and retry
+	var buf bytes.Buffer
+	_, err = b.WriteTo(&buf)
+	assert.NoError(t, err)
+	b.Reset()
+	assert.NoError(t, b.Err())
+	assert.NoError(t, b.WriteInt(1667464245, 10))
+	assert.Equal(t, "1667464245", b.String())
+	assert.Equal(t, "a.b.c 12345 ", buf.String())
+
+	_, err = b.WriteString("1234567")
+	assert.Equal(t, ErrBuilderFull, err)
+	b.Truncate(4)
+	assert.NoError(t, b.Err())
+	assert.Equal(t, "1667", b.String())
+}
+
+func TestBoundedBuilder_Methods(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(b *BoundedBuilder) error
+		want string
+	}{
+		{"WriteBytes", func(b *BoundedBuilder) error { return b.WriteBytes([]byte("12345678")) }, "12345678"},
+		{"WriteRune", func(b *BoundedBuilder) error { _, err := b.WriteRune('世'); return err }, "世"},
+		{"WriteUint", func(b *BoundedBuilder) error { return b.WriteUint(1234567, 10) }, "1234567"},
+		{"WriteFloat", func(b *BoundedBuilder) error { return b.WriteFloat(1.25, 'f', -1, 64) }, "1.25"},
+		{"WriteBool", func(b *BoundedBuilder) error { return b.WriteBool(false) }, "false"},
+		{"WriteQuote", func(b *BoundedBuilder) error { return b.WriteQuote("a\n") }, `"a\n"`},
+		{"WriteTime", func(b *BoundedBuilder) error { return b.WriteTime(time.Unix(1, 0), TimeUnix) }, "1"},
+		{"WriteDuration", func(b *BoundedBuilder) error { return b.WriteDuration(time.Second) }, "1s"},
+		{"WriteJSONString", func(b *BoundedBuilder) error { return b.WriteJSONString("a\n") }, `"a\n"`},
+		{"Printf", func(b *BoundedBuilder) error { return b.Printf("%s=%d", "a", 1) }, "a=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoundedBuilder(10)
+			b.WriteString("..")
+			assert.NoError(t, tt.fn(b))
+			assert.Equal(t, ".."+tt.want, b.String())
+
+			b.Reset()
+			b.WriteString("1234567890")
+			assert.Equal(t, ErrBuilderFull, tt.fn(b))
+			assert.Equal(t, "1234567890", b.String())
+		})
+	}
+}
+
+func TestBoundedBuilder_Append(t *testing.T) {
+	b := NewBoundedBuilder(30)
+	record := func(sb *Builder) {
+		sb.WriteString("a.b.c 1.5 ")
+		sb.WriteInt(1667464245, 10)
+		sb.WriteByte('\n')
+	}
+	assert.NoError(t, b.Append(record))
+	assert.Equal(t, ErrBuilderFull, b.Append(record))
+	assert.Equal(t, "a.b.c 1.5 1667464245\n", b.String())
+}
@@ -0,0 +1,279 @@
+package stringutils
+
+import (
+	"io"
+	"time"
+)
+
+// DefaultFlushThreshold is a default flush threshold for BufferedBuilder
+const DefaultFlushThreshold = 4096
+
+var (
+	_ io.Writer       = (*BufferedBuilder)(nil)
+	_ io.ByteWriter   = (*BufferedBuilder)(nil)
+	_ io.StringWriter = (*BufferedBuilder)(nil)
+)
+
+// A BufferedBuilder is a Builder, which flush accumulated data to underlying io.Writer, when threshold is exceeded,
+// so can be used for stream huge data without holding it in memory.
+// Like bufio.Writer, if an error occurs writing to a io.Writer, no more data will be accepted
+// and all subsequent writes, and Flush, will return the error.
+type BufferedBuilder struct {
+	sb        Builder
+	w         io.Writer
+	threshold int
+	err       error
+}
+
+// NewBufferedBuilder return new BufferedBuilder, flushed to w, when accumulated data length exceed threshold
+// (DefaultFlushThreshold if threshold <= 0).
+func NewBufferedBuilder(w io.Writer, threshold int) *BufferedBuilder {
+	if threshold <= 0 {
+		threshold = DefaultFlushThreshold
+	}
+	b := &BufferedBuilder{w: w, threshold: threshold}
+	b.sb.Grow(threshold + threshold/4)
+	return b
+}
+
+// Reset discards any unflushed data, resets error, and set the underlying writer to w.
+func (b *BufferedBuilder) Reset(w io.Writer) {
+	b.sb.Reset()
+	b.w = w
+	b.err = nil
+}
+
+// Threshold returns the flush threshold.
+func (b *BufferedBuilder) Threshold() int {
+	return b.threshold
+}
+
+// Buffered returns the number of bytes that have been written into the current buffer.
+func (b *BufferedBuilder) Buffered() int {
+	return len(b.sb.data)
+}
+
+// Err returns the sticky write error.
+func (b *BufferedBuilder) Err() error {
+	return b.err
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (b *BufferedBuilder) Flush() error {
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.sb.data) == 0 {
+		return nil
+	}
+	n, err := b.w.Write(b.sb.data)
+	if n < len(b.sb.data) && err == nil {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		if n > 0 && n < len(b.sb.data) {
+			copy(b.sb.data, b.sb.data[n:])
+		}
+		b.sb.data = b.sb.data[:len(b.sb.data)-n]
+		b.err = err
+		return err
+	}
+	b.sb.Reset()
+	return nil
+}
+
+// check flush buffer, if threshold exceeded.
+func (b *BufferedBuilder) check() error {
+	if len(b.sb.data) > b.threshold {
+		return b.Flush()
+	}
+	return nil
+}
+
+// Append calls f for write into underlying Builder (flushed, if threshold exceeded after).
+// Can be used for Builder append methods without BufferedBuilder equivalent.
+func (b *BufferedBuilder) Append(f func(sb *Builder)) error {
+	if b.err != nil {
+		return b.err
+	}
+	f(&b.sb)
+	return b.check()
+}
+
+// Write like WriteBytes, but realized io.Writer interface.
+// Large writes with empty buffer are passed to underlying io.Writer directly.
+// On error n is the number of bytes from p, written to the underlying io.Writer
+// (data, buffered after write error, will never be written).
+func (b *BufferedBuilder) Write(bytes []byte) (n int, err error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if len(b.sb.data) == 0 && len(bytes) > b.threshold {
+		n, err = b.w.Write(bytes)
+		if n < len(bytes) && err == nil {
+			err = io.ErrShortWrite
+		}
+		b.err = err
+		return n, err
+	}
+	b.sb.WriteBytes(bytes)
+	return b.written(len(bytes), b.check())
+}
+
+// written returns the number of last appended n bytes, which are written to the underlying io.Writer.
+// On flush error unwritten data is kept in buffer.
+func (b *BufferedBuilder) written(n int, err error) (int, error) {
+	if err != nil {
+		if m := n - len(b.sb.data); m < n {
+			if m < 0 {
+				m = 0
+			}
+			return m, err
+		}
+	}
+	return n, err
+}
+
+// WriteBytes appends the contents of p to b's buffer.
+func (b *BufferedBuilder) WriteBytes(bytes []byte) error {
+	_, err := b.Write(bytes)
+	return err
+}
+
+// WriteString appends the contents of s to b's buffer.
+// On error n is the number of bytes from s, written to the underlying io.Writer, like Write.
+func (b *BufferedBuilder) WriteString(s string) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	_, _ = b.sb.WriteString(s)
+	return b.written(len(s), b.check())
+}
+
+// WriteByte appends the byte c to b's buffer.
+func (b *BufferedBuilder) WriteByte(c byte) error {
+	if b.err != nil {
+		return b.err
+	}
+	_ = b.sb.WriteByte(c)
+	return b.check()
+}
+
+// WriteRune appends the UTF-8 encoding of Unicode code point r to b's buffer.
+func (b *BufferedBuilder) WriteRune(r rune) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	length := len(b.sb.data)
+	_, _ = b.sb.WriteRune(r)
+	return len(b.sb.data) - length, b.check()
+}
+
+// WriteInt appends the string form of the integer i, as generated by FormatInt.
+func (b *BufferedBuilder) WriteInt(i int64, base int) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteInt(i, base)
+	return b.check()
+}
+
+// WriteUint appends the string form of the unsigned integer i, as generated by FormatUint.
+func (b *BufferedBuilder) WriteUint(i uint64, base int) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteUint(i, base)
+	return b.check()
+}
+
+// WriteFloat appends the string form of the floating-point number f,
+// as generated by FormatFloat.
+func (b *BufferedBuilder) WriteFloat(f float64, fmt byte, prec, bitSize int) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteFloat(f, fmt, prec, bitSize)
+	return b.check()
+}
+
+// WriteBool appends the string form of the bool v, as generated by FormatBool.
+func (b *BufferedBuilder) WriteBool(v bool) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteBool(v)
+	return b.check()
+}
+
+// WriteQuote appends the string form of the quoted string s, as generated by Quote.
+func (b *BufferedBuilder) WriteQuote(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteQuote(s)
+	return b.check()
+}
+
+// WriteQuoteToASCII appends the string form of the single-quoted string s, as generated by QuoteToASCII.
+func (b *BufferedBuilder) WriteQuoteToASCII(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteQuoteToASCII(s)
+	return b.check()
+}
+
+// WriteTime appends the textual representation of t, like Builder.WriteTime.
+func (b *BufferedBuilder) WriteTime(t time.Time, layout string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteTime(t, layout)
+	return b.check()
+}
+
+// WriteDuration appends the string form of the duration d, like Builder.WriteDuration.
+func (b *BufferedBuilder) WriteDuration(d time.Duration) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteDuration(d)
+	return b.check()
+}
+
+// WriteJSONString appends the quoted JSON string form of s, like Builder.WriteJSONString.
+func (b *BufferedBuilder) WriteJSONString(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteJSONString(s)
+	return b.check()
+}
+
+// WriteStringUpper appends s with all Unicode letters mapped to their upper case, like Builder.WriteStringUpper.
+func (b *BufferedBuilder) WriteStringUpper(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteStringUpper(s)
+	return b.check()
+}
+
+// WriteStringLower appends s with all Unicode letters mapped to their lower case, like Builder.WriteStringLower.
+func (b *BufferedBuilder) WriteStringLower(s string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.WriteStringLower(s)
+	return b.check()
+}
+
+// Printf appends formatted string, like Builder.Printf.
+func (b *BufferedBuilder) Printf(format string, args ...interface{}) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sb.Printf(format, args...)
+	return b.check()
+}
@@ -0,0 +1,161 @@
+package stringutils
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countWriter counts Write calls
+type countWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+var errTestWrite = errors.New("write failed")
+
+// failWriter accept n bytes, after it fails
+type failWriter struct {
+	bytes.Buffer
+	n int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.Len()+len(p) <= w.n {
+		return w.Buffer.Write(p)
+	}
+	n := w.n - w.Len()
+	w.Buffer.Write(p[:n])
+	return n, errTestWrite
+}
+
+func TestBufferedBuilder(t *testing.T) {
+	var w countWriter
+	b := NewBufferedBuilder(&w, 16)
+	assert.Equal(t, 16, b.Threshold())
+
+	_, err := b.WriteString("0123456789")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, b.Buffered())
+	assert.Equal(t, 0, w.writes)
+
+	assert.NoError(t, b.WriteInt(-12345, 10))
+	assert.NoError(t, b.WriteUint(678, 10))
+	// threshold exceeded
+	assert.Equal(t, 0, b.Buffered())
+	assert.Equal(t, 1, w.writes)
+	assert.Equal(t, "0123456789-12345678", w.String())
+
+	assert.NoError(t, b.WriteFloat(1.5, 'f', -1, 64))
+	assert.NoError(t, b.WriteBool(true))
+	assert.NoError(t, b.WriteByte(' '))
+	n, err := b.WriteRune('世')
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.NoError(t, b.WriteStringUpper("up"))
+	assert.NoError(t, b.WriteStringLower("LOW"))
+	assert.NoError(t, b.WriteQuote("q"))
+	assert.NoError(t, b.WriteQuoteToASCII("世"))
+	assert.NoError(t, b.WriteDuration(1500*time.Millisecond))
+	assert.NoError(t, b.WriteTime(time.Unix(0, 0).UTC(), time.RFC3339))
+	assert.NoError(t, b.WriteJSONString("j\n"))
+	assert.NoError(t, b.Printf(" %d%%", 50))
+	assert.NoError(t, b.Append(func(sb *Builder) {
+		sb.WriteIntGrouped(1234567, ",")
+	}))
+	assert.NoError(t, b.WriteBytes([]byte("!")))
+
+	assert.NoError(t, b.Flush())
+	assert.Equal(t, 0, b.Buffered())
+	assert.Equal(t, `0123456789-123456781.5true 世UPlow"q""\u4e16"1.5s1970-01-01T00:00:00Z"j\n" 50%1,234,567!`, w.String())
+
+	// empty flush
+	writes := w.writes
+	assert.NoError(t, b.Flush())
+	assert.Equal(t, writes, w.writes)
+
+	// large write with empty buffer is passed directly
+	large := strings.Repeat("x", 100)
+	m, err := b.Write([]byte(large))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, m)
+	assert.Equal(t, writes+1, w.writes)
+	assert.True(t, strings.HasSuffix(w.String(), large))
+}
+
+func TestBufferedBuilder_Error(t *testing.T) {
+	w := &failWriter{n: 10}
+	b := NewBufferedBuilder(w, 8)
+
+	_, err := b.WriteString("0123")
+	assert.NoError(t, err)
+	// only "456789" from s is written
+	n, err := b.WriteString("456789abcdef")
+	assert.Equal(t, 6, n)
+	assert.Equal(t, errTestWrite, err)
+	assert.Equal(t, errTestWrite, b.Err())
+	assert.Equal(t, "0123456789", w.String())
+	// unwritten data is kept
+	assert.Equal(t, 6, b.Buffered())
+
+	// error is sticky
+	n, err = b.WriteString("more")
+	assert.Equal(t, 0, n)
+	assert.Equal(t, errTestWrite, err)
+	assert.Equal(t, errTestWrite, b.WriteInt(1, 10))
+	assert.Equal(t, errTestWrite, b.WriteStringLower("A"))
+	assert.Equal(t, errTestWrite, b.Append(func(sb *Builder) {}))
+	assert.Equal(t, errTestWrite, b.Flush())
+	assert.Equal(t, 6, b.Buffered())
+
+	// reset clear error
+	var buf bytes.Buffer
+	b.Reset(&buf)
+	assert.Nil(t, b.Err())
+	assert.Equal(t, 0, b.Buffered())
+	_, err = b.WriteString("ok")
+	assert.NoError(t, err)
+	assert.NoError(t, b.Flush())
+	assert.Equal(t, "ok", buf.String())
+
+	// short write
+	b.Reset(shortWriter{})
+	_, _ = b.WriteString("short")
+	assert.Equal(t, io.ErrShortWrite, b.Flush())
+	assert.Equal(t, io.ErrShortWrite, b.Err())
+
+	// nothing from p is written
+	b.Reset(&failWriter{n: 2})
+	_, err = b.Write([]byte("0123"))
+	assert.NoError(t, err)
+	n, err = b.Write([]byte("456789"))
+	assert.Equal(t, 0, n)
+	assert.Equal(t, errTestWrite, err)
+}
+
+func BenchmarkThis_BufferedBuilder(b *testing.B) {
+	bb := NewBufferedBuilder(ioutil.Discard, 0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = bb.WriteString("carbon.agents.cpu ")
+		_ = bb.WriteFloat(12.5, 'f', -1, 64)
+		_ = bb.WriteByte(' ')
+		_ = bb.WriteInt(1667464245, 10)
+		_ = bb.WriteByte('\n')
+	}
+	_ = bb.Flush()
+}
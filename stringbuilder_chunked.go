@@ -0,0 +1,284 @@
+package stringutils
+
+import (
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultChunkSize is a default chunk size for ChunkedBuilder
+const DefaultChunkSize = 64 * 1024
+
+var _ io.WriterTo = (*ChunkedBuilder)(nil)
+
+// A ChunkedBuilder is used to efficiently build a very large string using Write methods.
+// Unlike Builder, it appends into a list of fixed-size chunks, so accumulated data never copied on grow.
+// The zero value is ready to use (with DefaultChunkSize).
+// Do not copy a non-zero ChunkedBuilder.
+type ChunkedBuilder struct {
+	chunks    [][]byte
+	cur       int // index of current chunk
+	length    int
+	chunkSize int
+
+	scratch Builder // for formatting values with unknown length
+}
+
+// NewChunkedBuilder return new ChunkedBuilder with chunkSize (DefaultChunkSize if chunkSize <= 0).
+func NewChunkedBuilder(chunkSize int) *ChunkedBuilder {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &ChunkedBuilder{chunkSize: chunkSize}
+}
+
+// ChunkSize returns the chunk size.
+func (b *ChunkedBuilder) ChunkSize() int {
+	if b.chunkSize <= 0 {
+		return DefaultChunkSize
+	}
+	return b.chunkSize
+}
+
+// Len returns the number of accumulated bytes.
+func (b *ChunkedBuilder) Len() int {
+	return b.length
+}
+
+// Chunks returns the number of allocated chunks.
+func (b *ChunkedBuilder) Chunks() int {
+	return len(b.chunks)
+}
+
+// Reset resets the ChunkedBuilder to be empty (allocated chunks are reused).
+func (b *ChunkedBuilder) Reset() {
+	for i := 0; i <= b.cur && i < len(b.chunks); i++ {
+		b.chunks[i] = b.chunks[i][:0]
+	}
+	b.cur = 0
+	b.length = 0
+}
+
+// Release resets the ChunkedBuilder to be empty and free chunks
+func (b *ChunkedBuilder) Release() {
+	b.chunks = nil
+	b.cur = 0
+	b.length = 0
+	b.scratch.Release()
+}
+
+// chunk returns the current chunk with free space (allocate next chunk, if needed).
+func (b *ChunkedBuilder) chunk() []byte {
+	if len(b.chunks) == 0 {
+		b.chunks = append(b.chunks, make([]byte, 0, b.ChunkSize()))
+		return b.chunks[0]
+	}
+	c := b.chunks[b.cur]
+	if len(c) == cap(c) {
+		b.cur++
+		if b.cur == len(b.chunks) {
+			b.chunks = append(b.chunks, make([]byte, 0, b.ChunkSize()))
+		}
+		c = b.chunks[b.cur]
+	}
+	return c
+}
+
+// Write like WriteBytes, but realized io.Writer interface
+func (b *ChunkedBuilder) Write(bytes []byte) (int, error) {
+	b.WriteBytes(bytes)
+	return len(bytes), nil
+}
+
+// WriteBytes appends the contents of p to b's buffer.
+func (b *ChunkedBuilder) WriteBytes(bytes []byte) {
+	b.length += len(bytes)
+	for len(bytes) > 0 {
+		c := b.chunk()
+		n := copy(c[len(c):cap(c)], bytes)
+		b.chunks[b.cur] = c[:len(c)+n]
+		bytes = bytes[n:]
+	}
+}
+
+// WriteString appends the contents of s to b's buffer.
+func (b *ChunkedBuilder) WriteString(s string) (int, error) {
+	n := len(s)
+	b.length += n
+	for len(s) > 0 {
+		c := b.chunk()
+		m := copy(c[len(c):cap(c)], s)
+		b.chunks[b.cur] = c[:len(c)+m]
+		s = s[m:]
+	}
+	return n, nil
+}
+
+// WriteByte appends the byte c to b's buffer.
+func (b *ChunkedBuilder) WriteByte(c byte) error {
+	chunk := b.chunk()
+	b.chunks[b.cur] = append(chunk, c)
+	b.length++
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of Unicode code point r to b's buffer.
+func (b *ChunkedBuilder) WriteRune(r rune) (int, error) {
+	if r < utf8.RuneSelf {
+		return 1, b.WriteByte(byte(r))
+	}
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	b.WriteBytes(buf[:n])
+	return n, nil
+}
+
+// WriteInt appends the string form of the integer i, as generated by FormatInt.
+func (b *ChunkedBuilder) WriteInt(i int64, base int) {
+	var buf [65]byte
+	b.WriteBytes(strconv.AppendInt(buf[:0], i, base))
+}
+
+// WriteUint appends the string form of the unsigned integer i, as generated by FormatUint.
+func (b *ChunkedBuilder) WriteUint(i uint64, base int) {
+	var buf [64]byte
+	b.WriteBytes(strconv.AppendUint(buf[:0], i, base))
+}
+
+// WriteFloat appends the string form of the floating-point number f,
+// as generated by FormatFloat.
+func (b *ChunkedBuilder) WriteFloat(f float64, fmt byte, prec, bitSize int) {
+	var buf [64]byte
+	b.WriteBytes(strconv.AppendFloat(buf[:0], f, fmt, prec, bitSize))
+}
+
+// WriteBool appends the string form of the bool v, as generated by FormatBool.
+func (b *ChunkedBuilder) WriteBool(v bool) {
+	if v {
+		b.WriteString("true")
+	} else {
+		b.WriteString("false")
+	}
+}
+
+// WriteWith appends the data, written by f to temporary Builder (reused between calls).
+// Can be used for Builder append methods without ChunkedBuilder equivalent.
+func (b *ChunkedBuilder) WriteWith(f func(sb *Builder)) {
+	b.scratch.Reset()
+	f(&b.scratch)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteQuote appends the string form of the quoted string s, as generated by Quote.
+func (b *ChunkedBuilder) WriteQuote(s string) {
+	b.scratch.Reset()
+	b.scratch.WriteQuote(s)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteQuoteToASCII appends the string form of the single-quoted string s, as generated by QuoteToASCII.
+func (b *ChunkedBuilder) WriteQuoteToASCII(s string) {
+	b.scratch.Reset()
+	b.scratch.WriteQuoteToASCII(s)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteQuoteToGraphic appends the string form of the quoted string s, as generated by QuoteToGraphic.
+func (b *ChunkedBuilder) WriteQuoteToGraphic(s string) {
+	b.scratch.Reset()
+	b.scratch.WriteQuoteToGraphic(s)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteQuoteRune appends the string form of the quoted rune r, as generated by QuoteRune.
+func (b *ChunkedBuilder) WriteQuoteRune(r rune) {
+	var buf [32]byte
+	b.WriteBytes(strconv.AppendQuoteRune(buf[:0], r))
+}
+
+// WriteQuoteRuneToASCII appends the string form of the single-quoted rune r, as generated by QuoteRuneToASCII.
+func (b *ChunkedBuilder) WriteQuoteRuneToASCII(r rune) {
+	var buf [32]byte
+	b.WriteBytes(strconv.AppendQuoteRuneToASCII(buf[:0], r))
+}
+
+// WriteQuoteRuneToGraphic appends the string form of the quoted rune r, as generated by QuoteRuneToGraphic.
+func (b *ChunkedBuilder) WriteQuoteRuneToGraphic(r rune) {
+	var buf [32]byte
+	b.WriteBytes(strconv.AppendQuoteRuneToGraphic(buf[:0], r))
+}
+
+// WriteTime appends the textual representation of t, like Builder.WriteTime.
+func (b *ChunkedBuilder) WriteTime(t time.Time, layout string) {
+	b.scratch.Reset()
+	b.scratch.WriteTime(t, layout)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteDuration appends the string form of the duration d, like Builder.WriteDuration.
+func (b *ChunkedBuilder) WriteDuration(d time.Duration) {
+	var buf [32]byte
+	sb := Builder{data: buf[:0]}
+	sb.WriteDuration(d)
+	b.WriteBytes(sb.data)
+}
+
+// WriteJSONString appends the quoted JSON string form of s, like Builder.WriteJSONString.
+func (b *ChunkedBuilder) WriteJSONString(s string) {
+	b.scratch.Reset()
+	b.scratch.WriteJSONString(s)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteStringUpper appends s with all Unicode letters mapped to their upper case, like Builder.WriteStringUpper.
+func (b *ChunkedBuilder) WriteStringUpper(s string) {
+	b.scratch.Reset()
+	b.scratch.WriteStringUpper(s)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteStringLower appends s with all Unicode letters mapped to their lower case, like Builder.WriteStringLower.
+func (b *ChunkedBuilder) WriteStringLower(s string) {
+	b.scratch.Reset()
+	b.scratch.WriteStringLower(s)
+	b.WriteBytes(b.scratch.data)
+}
+
+// Printf appends formatted string, like Builder.Printf.
+func (b *ChunkedBuilder) Printf(format string, args ...interface{}) {
+	b.scratch.Reset()
+	b.scratch.Printf(format, args...)
+	b.WriteBytes(b.scratch.data)
+}
+
+// WriteTo writes accumulated data to w. The ChunkedBuilder is not drained.
+func (b *ChunkedBuilder) WriteTo(w io.Writer) (n int64, err error) {
+	for i := 0; i <= b.cur && i < len(b.chunks); i++ {
+		c := b.chunks[i]
+		if len(c) == 0 {
+			continue
+		}
+		m, err := w.Write(c)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+		if m != len(c) {
+			return n, io.ErrShortWrite
+		}
+	}
+	return n, nil
+}
+
+// String returns the accumulated string (copied once to new allocated buffer).
+func (b *ChunkedBuilder) String() string {
+	if b.length == 0 {
+		return ""
+	}
+	buf := make([]byte, 0, b.length)
+	for i := 0; i <= b.cur && i < len(b.chunks); i++ {
+		buf = append(buf, b.chunks[i]...)
+	}
+	return UnsafeString(buf)
+}
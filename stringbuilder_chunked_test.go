@@ -0,0 +1,153 @@
+package stringutils
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkedBuilder(t *testing.T) {
+	const s0 = "hello 世界"
+
+	var (
+		b  = NewChunkedBuilder(7)
+		sb Builder
+	)
+	assert.Equal(t, 7, b.ChunkSize())
+	assert.Equal(t, "", b.String())
+
+	tests := []struct {
+		name string
+		fn   func(b *ChunkedBuilder)
+		sfn  func(sb *Builder)
+	}{
+		{"Write", func(b *ChunkedBuilder) { b.Write([]byte(s0)) }, func(sb *Builder) { sb.Write([]byte(s0)) }},
+		{"WriteString", func(b *ChunkedBuilder) { b.WriteString(s0) }, func(sb *Builder) { sb.WriteString(s0) }},
+		{"WriteByte", func(b *ChunkedBuilder) { b.WriteByte('c') }, func(sb *Builder) { sb.WriteByte('c') }},
+		{"WriteRune", func(b *ChunkedBuilder) { b.WriteRune('a') }, func(sb *Builder) { sb.WriteRune('a') }},
+		{"WriteRuneWide", func(b *ChunkedBuilder) { b.WriteRune('世') }, func(sb *Builder) { sb.WriteRune('世') }},
+		{"WriteInt", func(b *ChunkedBuilder) { b.WriteInt(math.MinInt64, 2) }, func(sb *Builder) { sb.WriteInt(math.MinInt64, 2) }},
+		{"WriteUint", func(b *ChunkedBuilder) { b.WriteUint(math.MaxUint64, 10) }, func(sb *Builder) { sb.WriteUint(math.MaxUint64, 10) }},
+		{"WriteFloat", func(b *ChunkedBuilder) { b.WriteFloat(1e300, 'f', -1, 64) }, func(sb *Builder) { sb.WriteFloat(1e300, 'f', -1, 64) }},
+		{"WriteBool", func(b *ChunkedBuilder) { b.WriteBool(true); b.WriteBool(false) }, func(sb *Builder) { sb.WriteBool(true); sb.WriteBool(false) }},
+		{"WriteQuote", func(b *ChunkedBuilder) { b.WriteQuote(s0) }, func(sb *Builder) { sb.WriteQuote(s0) }},
+		{"WriteQuoteToASCII", func(b *ChunkedBuilder) { b.WriteQuoteToASCII(s0) }, func(sb *Builder) { sb.WriteQuoteToASCII(s0) }},
+		{"WriteQuoteToGraphic", func(b *ChunkedBuilder) { b.WriteQuoteToGraphic(s0) }, func(sb *Builder) { sb.WriteQuoteToGraphic(s0) }},
+		{"WriteQuoteRune", func(b *ChunkedBuilder) { b.WriteQuoteRune('世') }, func(sb *Builder) { sb.WriteQuoteRune('世') }},
+		{"WriteQuoteRuneToASCII", func(b *ChunkedBuilder) { b.WriteQuoteRuneToASCII('世') }, func(sb *Builder) { sb.WriteQuoteRuneToASCII('世') }},
+		{"WriteQuoteRuneToGraphic", func(b *ChunkedBuilder) { b.WriteQuoteRuneToGraphic('世') }, func(sb *Builder) { sb.WriteQuoteRuneToGraphic('世') }},
+		{"WriteTime", func(b *ChunkedBuilder) { b.WriteTime(time.Unix(1, 5).UTC(), time.RFC3339Nano) }, func(sb *Builder) { sb.WriteTime(time.Unix(1, 5).UTC(), time.RFC3339Nano) }},
+		{"WriteDuration", func(b *ChunkedBuilder) { b.WriteDuration(-time.Hour - 1) }, func(sb *Builder) { sb.WriteDuration(-time.Hour - 1) }},
+		{"WriteJSONString", func(b *ChunkedBuilder) { b.WriteJSONString(s0 + "\n") }, func(sb *Builder) { sb.WriteJSONString(s0 + "\n") }},
+		{"WriteStringUpper", func(b *ChunkedBuilder) { b.WriteStringUpper(s0) }, func(sb *Builder) { sb.WriteStringUpper(s0) }},
+		{"WriteStringLower", func(b *ChunkedBuilder) { b.WriteStringLower("ABC") }, func(sb *Builder) { sb.WriteStringLower("ABC") }},
+		{"Printf", func(b *ChunkedBuilder) { b.Printf("%s=%5d", "a", 1) }, func(sb *Builder) { sb.Printf("%s=%5d", "a", 1) }},
+		{"WriteWith", func(b *ChunkedBuilder) { b.WriteWith(func(sb *Builder) { sb.WriteString(s0) }) }, func(sb *Builder) { sb.WriteString(s0) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(b)
+			tt.sfn(&sb)
+			assert.Equal(t, sb.String(), b.String())
+			assert.Equal(t, sb.Len(), b.Len())
+		})
+	}
+
+	var buf bytes.Buffer
+	n, err := b.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(sb.Len()), n)
+	assert.Equal(t, sb.String(), buf.String())
+
+	// reuse chunks
+	chunks := b.Chunks()
+	b.Reset()
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, "", b.String())
+	b.WriteString(sb.String())
+	assert.Equal(t, sb.String(), b.String())
+	assert.Equal(t, chunks, b.Chunks())
+
+	b.Release()
+	assert.Equal(t, 0, b.Chunks())
+	assert.Equal(t, "", b.String())
+}
+
+func TestChunkedBuilder_Zero(t *testing.T) {
+	var b ChunkedBuilder
+	s := strings.Repeat("0123456789", DefaultChunkSize/5)
+	b.WriteString(s)
+	assert.Equal(t, DefaultChunkSize, b.ChunkSize())
+	assert.Equal(t, 2, b.Chunks())
+	assert.Equal(t, s, b.String())
+}
+
+func BenchmarkThis_ChunkedBuilder_WriteString(b *testing.B) {
+	var sb ChunkedBuilder
+	s := "asdfghjklqwertyuiopzxcvbnm1234567890"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if sb.Len()+len(s) > 1000000 {
+			sb.Reset()
+		}
+		sb.WriteString(s)
+	}
+}
+
+func BenchmarkThis_ChunkedBuilder_WriteInt(b *testing.B) {
+	var sb ChunkedBuilder
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if sb.Len() > 1000000 {
+			sb.Reset()
+		}
+		sb.WriteInt(int64(i), 10)
+	}
+}
+
+func BenchmarkThis_ChunkedBuilder_Large(b *testing.B) {
+	s := strings.Repeat("asdfghjklqwertyuiopzxcvbnm1234567890", 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var sb ChunkedBuilder
+		for j := 0; j < 10000; j++ {
+			sb.WriteString(s)
+		}
+	}
+}
+
+func BenchmarkThis_Builder_Large(b *testing.B) {
+	s := strings.Repeat("asdfghjklqwertyuiopzxcvbnm1234567890", 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var sb Builder
+		for j := 0; j < 10000; j++ {
+			sb.WriteString(s)
+		}
+	}
+}
+
+func TestChunkedBuilder_WriteToError(t *testing.T) {
+	b := NewChunkedBuilder(4)
+	b.WriteString("12345678")
+	n, err := b.WriteTo(shortWriter{})
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, io.ErrShortWrite, err)
+}
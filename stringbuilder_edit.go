@@ -0,0 +1,101 @@
+package stringutils
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// ReplaceRange replaces bytes [start:end] with s (in-place, reuse capacity).
+// Return ErrOutOfRange if not 0 <= start <= end <= Len().
+// Accumulated bytes are shifted, so strings, returned by String() before, can be corrupted.
+func (sb *Builder) ReplaceRange(start, end int, s string) error {
+	length := len(sb.data)
+	if start < 0 || end < start || end > length {
+		return ErrOutOfRange
+	}
+	if n := len(s) - (end - start); n > 0 {
+		sb.extend(n)
+		copy(sb.data[end+n:], sb.data[end:length])
+	} else if n < 0 {
+		copy(sb.data[end+n:], sb.data[end:length])
+		sb.data = sb.data[:length+n]
+	}
+	copy(sb.data[start:], s)
+	return nil
+}
+
+// Insert inserts s at position pos (in-place, reuse capacity).
+// Return ErrOutOfRange if not 0 <= pos <= Len().
+func (sb *Builder) Insert(pos int, s string) error {
+	return sb.ReplaceRange(pos, pos, s)
+}
+
+// Delete removes bytes [start:end] (in-place).
+// Return ErrOutOfRange if not 0 <= start <= end <= Len().
+func (sb *Builder) Delete(start, end int) error {
+	return sb.ReplaceRange(start, end, "")
+}
+
+// isRuneBoundary check that pos is not inside of UTF-8 encoded rune.
+func (sb *Builder) isRuneBoundary(pos int) bool {
+	return pos <= 0 || pos >= len(sb.data) || utf8.RuneStart(sb.data[pos])
+}
+
+// ReplaceRangeUTF8 is like ReplaceRange, but also return ErrNotRuneBoundary if start or end is inside of UTF-8 encoded rune.
+func (sb *Builder) ReplaceRangeUTF8(start, end int, s string) error {
+	if start < 0 || end < start || end > len(sb.data) {
+		return ErrOutOfRange
+	}
+	if !sb.isRuneBoundary(start) || !sb.isRuneBoundary(end) {
+		return ErrNotRuneBoundary
+	}
+	return sb.ReplaceRange(start, end, s)
+}
+
+// InsertUTF8 is like Insert, but also return ErrNotRuneBoundary if pos is inside of UTF-8 encoded rune.
+func (sb *Builder) InsertUTF8(pos int, s string) error {
+	return sb.ReplaceRangeUTF8(pos, pos, s)
+}
+
+// DeleteUTF8 is like Delete, but also return ErrNotRuneBoundary if start or end is inside of UTF-8 encoded rune.
+func (sb *Builder) DeleteUTF8(start, end int) error {
+	return sb.ReplaceRangeUTF8(start, end, "")
+}
+
+// ReplaceAllInPlace replaces all non-overlapping instances of old by new (in-place, reuse capacity).
+// Return change flag. If old is empty, nothing replaced.
+// Accumulated bytes are shifted, so strings, returned by String() before, can be corrupted.
+func (sb *Builder) ReplaceAllInPlace(old, new string) bool {
+	if len(old) == 0 || old == new {
+		return false
+	}
+	oldBytes := UnsafeStringBytes(&old)
+	n := bytes.Count(sb.data, oldBytes)
+	if n == 0 {
+		return false
+	}
+
+	// read from r, write to w (w <= r always, so unread bytes are not overwritten)
+	w, r := 0, 0
+	end := len(sb.data)
+	if len(new) > len(old) {
+		// shift to the end for free space
+		r = n * (len(new) - len(old))
+		sb.extend(r)
+		copy(sb.data[r:], sb.data[:end])
+		end += r
+	}
+	for {
+		i := bytes.Index(sb.data[r:end], oldBytes)
+		if i == -1 {
+			break
+		}
+		w += copy(sb.data[w:], sb.data[r:r+i])
+		w += copy(sb.data[w:], new)
+		r += i + len(old)
+	}
+	w += copy(sb.data[w:], sb.data[r:end])
+	sb.data = sb.data[:w]
+
+	return true
+}
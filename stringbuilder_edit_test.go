@@ -0,0 +1,127 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_ReplaceRange(t *testing.T) {
+	tests := []struct {
+		s          string
+		start, end int
+		new        string
+		want       string
+		wantErr    error
+	}{
+		{"SELECT 1", 0, 0, "/* q */ ", "/* q */ SELECT 1", nil},
+		{"a, b, c, ", 7, 9, "", "a, b, c", nil},
+		{"a, b, c", 1, 2, " AND", "a AND b, c", nil},
+		{"a AND b", 1, 6, ",", "a,b", nil},
+		{"abc", 3, 3, "d", "abcd", nil},
+		{"abc", 0, 3, "xyz", "xyz", nil},
+		{"abc", -1, 1, "", "abc", ErrOutOfRange},
+		{"abc", 2, 1, "", "abc", ErrOutOfRange},
+		{"abc", 1, 4, "", "abc", ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			var sb Builder
+			sb.WriteString(tt.s)
+			err := sb.ReplaceRange(tt.start, tt.end, tt.new)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestBuilder_InsertDelete(t *testing.T) {
+	var sb Builder
+	sb.Grow(64)
+	sb.WriteString("id, name, ")
+
+	assert.NoError(t, sb.Insert(0, "SELECT "))
+	assert.NoError(t, sb.Delete(sb.Len()-2, sb.Len()))
+	assert.NoError(t, sb.Insert(sb.Len(), " FROM t"))
+	assert.Equal(t, "SELECT id, name FROM t", sb.String())
+	assert.Equal(t, 64, sb.Cap())
+
+	assert.Equal(t, ErrOutOfRange, sb.Insert(sb.Len()+1, "x"))
+	assert.Equal(t, ErrOutOfRange, sb.Delete(1, sb.Len()+1))
+}
+
+func TestBuilder_EditUTF8(t *testing.T) {
+	var sb Builder
+	sb.WriteString("тест")
+
+	assert.Equal(t, ErrNotRuneBoundary, sb.InsertUTF8(1, "x"))
+	assert.Equal(t, ErrNotRuneBoundary, sb.DeleteUTF8(0, 3))
+	assert.Equal(t, ErrNotRuneBoundary, sb.ReplaceRangeUTF8(3, 4, "x"))
+	assert.Equal(t, ErrOutOfRange, sb.ReplaceRangeUTF8(0, 9, "x"))
+	assert.Equal(t, "тест", sb.String())
+
+	assert.NoError(t, sb.InsertUTF8(2, "x"))
+	assert.Equal(t, "тxест", sb.String())
+	assert.NoError(t, sb.DeleteUTF8(2, 3))
+	assert.Equal(t, "тест", sb.String())
+	assert.NoError(t, sb.ReplaceRangeUTF8(6, 8, "ь"))
+	assert.Equal(t, "тесь", sb.String())
+	assert.NoError(t, sb.InsertUTF8(sb.Len(), "!"))
+	assert.Equal(t, "тесь!", sb.String())
+}
+
+func TestBuilder_ReplaceAllInPlace(t *testing.T) {
+	for _, tt := range ReplaceTests {
+		if tt.n != -1 || tt.old == "" {
+			continue
+		}
+		t.Run(tt.in+" "+tt.old+" "+tt.new, func(t *testing.T) {
+			var sb Builder
+			sb.WriteString(tt.in)
+			changed := sb.ReplaceAllInPlace(tt.old, tt.new)
+			assert.Equal(t, tt.out, sb.String())
+			assert.Equal(t, tt.changed, changed)
+		})
+	}
+
+	tests := []struct {
+		s, old, new string
+	}{
+		{"aaaa", "aa", "b"},
+		{"aaaaa", "aa", "bbb"},
+		{"a.b.c.d", ".", "::"},
+		{"a::b::c", "::", "."},
+		{"тест-тест", "ст", "СТ"},
+		{"", "a", "b"},
+		{"abc", "", "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s+" "+tt.old+" "+tt.new, func(t *testing.T) {
+			var sb Builder
+			sb.WriteString(tt.s)
+			want := tt.s
+			if tt.old != "" {
+				want = strings.ReplaceAll(tt.s, tt.old, tt.new)
+			}
+			changed := sb.ReplaceAllInPlace(tt.old, tt.new)
+			assert.Equal(t, want, sb.String())
+			assert.Equal(t, want != tt.s, changed)
+		})
+	}
+}
+
+func BenchmarkThis_Builder_ReplaceAllInPlace(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	s := "test1.2.test3.4.5"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteString(s)
+		sb.ReplaceAllInPlace(".", "::")
+	}
+}
@@ -0,0 +1,92 @@
+package stringutils
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// WriteHex appends the lower-case hexadecimal encoding of src, as generated by hex.EncodeToString.
+func (sb *Builder) WriteHex(src []byte) {
+	pos := sb.extend(hex.EncodedLen(len(src)))
+	hex.Encode(sb.data[pos:], src)
+}
+
+// WriteHexUpper appends the upper-case hexadecimal encoding of src.
+func (sb *Builder) WriteHexUpper(src []byte) {
+	pos := sb.extend(hex.EncodedLen(len(src)))
+	b := sb.data[pos:]
+	for i, c := range src {
+		b[2*i] = hexDigitsUpper[c>>4]
+		b[2*i+1] = hexDigitsUpper[c&0xF]
+	}
+}
+
+// WriteBase64 appends the base64 encoding of src with encoding enc, as generated by enc.EncodeToString.
+func (sb *Builder) WriteBase64(enc *base64.Encoding, src []byte) {
+	pos := sb.extend(enc.EncodedLen(len(src)))
+	enc.Encode(sb.data[pos:], src)
+}
+
+// WriteBase32 appends the base32 encoding of src with encoding enc, as generated by enc.EncodeToString.
+func (sb *Builder) WriteBase32(enc *base32.Encoding, src []byte) {
+	pos := sb.extend(enc.EncodedLen(len(src)))
+	enc.Encode(sb.data[pos:], src)
+}
+
+// hex dump line: offset (8 hex digits and 2 spaces), 16 hex encoded bytes (3 bytes per byte and additional space after 8th byte),
+// space and bar, up to 16 chars and bar with new line
+const hexDumpLineLen = 10 + 16*3 + 1 + 2 + 2
+
+// WriteHexDump appends a hex dump of src, as generated by hex.Dump.
+func (sb *Builder) WriteHexDump(src []byte) {
+	if len(src) == 0 {
+		return
+	}
+	lines := (len(src) + 15) / 16
+	pos := sb.extend(lines*hexDumpLineLen + len(src))
+	b := sb.data[pos:]
+	for offset := 0; offset < len(src); offset += 16 {
+		line := src[offset:]
+		if len(line) > 16 {
+			line = line[:16]
+		}
+		off := uint32(offset)
+		for i := 7; i >= 0; i-- {
+			b[i] = hexDigits[off&0xF]
+			off >>= 4
+		}
+		b[8] = ' '
+		b[9] = ' '
+		p := 10
+		for i := 0; i < 16; i++ {
+			if i < len(line) {
+				b[p] = hexDigits[line[i]>>4]
+				b[p+1] = hexDigits[line[i]&0xF]
+			} else {
+				b[p] = ' '
+				b[p+1] = ' '
+			}
+			b[p+2] = ' '
+			p += 3
+			if i == 7 {
+				// There's an additional space after the 8th byte.
+				b[p] = ' '
+				p++
+			}
+		}
+		b[p] = ' '
+		b[p+1] = '|'
+		p += 2
+		for _, c := range line {
+			if c < 32 || c > 126 {
+				c = '.'
+			}
+			b[p] = c
+			p++
+		}
+		b[p] = '|'
+		b[p+1] = '\n'
+		b = b[p+2:]
+	}
+}
@@ -0,0 +1,100 @@
+package stringutils
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBuilder_WriteEncoded(t *testing.T) {
+	inputs := [][]byte{
+		nil,
+		{0},
+		[]byte("hello, world"),
+		[]byte("\x00\x01\x7f\x80\xff тест"),
+	}
+	for i := 0; i < 40; i++ {
+		b := make([]byte, i)
+		for j := range b {
+			b[j] = byte(j*7 + 30)
+		}
+		inputs = append(inputs, b)
+	}
+
+	tests := []struct {
+		name string
+		fn   func(sb *Builder, src []byte)
+		want func(src []byte) string
+	}{
+		{"WriteHex", (*Builder).WriteHex, hex.EncodeToString},
+		{"WriteHexUpper", (*Builder).WriteHexUpper, func(src []byte) string { return strings.ToUpper(hex.EncodeToString(src)) }},
+		{"WriteHexDump", (*Builder).WriteHexDump, hex.Dump},
+		{
+			"WriteBase64(Std)",
+			func(sb *Builder, src []byte) { sb.WriteBase64(base64.StdEncoding, src) },
+			base64.StdEncoding.EncodeToString,
+		},
+		{
+			"WriteBase64(RawURL)",
+			func(sb *Builder, src []byte) { sb.WriteBase64(base64.RawURLEncoding, src) },
+			base64.RawURLEncoding.EncodeToString,
+		},
+		{
+			"WriteBase32(Std)",
+			func(sb *Builder, src []byte) { sb.WriteBase32(base32.StdEncoding, src) },
+			base32.StdEncoding.EncodeToString,
+		},
+		{
+			"WriteBase32(HexNoPadding)",
+			func(sb *Builder, src []byte) { sb.WriteBase32(base32.HexEncoding.WithPadding(base32.NoPadding), src) },
+			base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString,
+		},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		for i, src := range inputs {
+			t.Run(tt.name+" #"+strconv.Itoa(i), func(t *testing.T) {
+				sb.Reset()
+				sb.WriteString("key:")
+				tt.fn(&sb, src)
+				want := "key:" + tt.want(src)
+				if sb.String() != want {
+					t.Errorf("%s(%q) = \n%q\nwant\n%q", tt.name, src, sb.String(), want)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkThis_Builder_WriteHex(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	src := []byte("0123456789abcdef0123456789abcdef")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteString("key:")
+		sb.WriteHex(src)
+	}
+}
+
+func BenchmarkStd_Hex_EncodeToString(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	src := []byte("0123456789abcdef0123456789abcdef")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteString("key:")
+		sb.WriteString(hex.EncodeToString(src))
+	}
+}
@@ -0,0 +1,40 @@
+package stringutils
+
+// Checkpoint is a saved Builder length, returned by Builder.Mark.
+// The zero value is a checkpoint at the start of the Builder.
+type Checkpoint struct {
+	length int
+}
+
+// Len returns the Builder length at the checkpoint.
+func (cp Checkpoint) Len() int {
+	return cp.length
+}
+
+// Mark returns checkpoint at the current Builder length for speculative writes (can be reverted with Rollback).
+// Marks can be nested: rollback to outer checkpoint also discards all inner checkpoints.
+//
+// Rollback only truncate accumulated bytes, so strings, returned by String() before the mark, are never corrupted by subsequent writes
+// (but strings, returned after the mark, can be overwritten after rollback).
+// In-place edit methods (ReplaceRange, Insert, Delete, ReplaceAllInPlace) break this guarantee.
+func (sb *Builder) Mark() Checkpoint {
+	return Checkpoint{length: len(sb.data)}
+}
+
+// Rollback reverts the Builder to the checkpoint cp, all data, written after the mark, is discarded.
+// Return ErrOutOfRange if Builder is shorter than checkpoint (checkpoint is discarded by the previous rollback, Truncate or Reset).
+func (sb *Builder) Rollback(cp Checkpoint) error {
+	if cp.length > len(sb.data) || cp.length < 0 {
+		return ErrOutOfRange
+	}
+	sb.data = sb.data[:cp.length]
+	return nil
+}
+
+// Written returns the bytes, written after the checkpoint cp (nil if checkpoint is discarded).
+func (sb *Builder) Written(cp Checkpoint) []byte {
+	if cp.length > len(sb.data) || cp.length < 0 {
+		return nil
+	}
+	return sb.data[cp.length:]
+}
@@ -0,0 +1,56 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_MarkRollback(t *testing.T) {
+	var sb Builder
+	assert.Equal(t, 0, sb.Mark().Len())
+
+	sb.WriteString("header;")
+	header := sb.String()
+
+	outer := sb.Mark()
+	assert.Equal(t, 7, outer.Len())
+	sb.WriteString("record1;")
+
+	inner := sb.Mark()
+	sb.WriteString("bad field")
+	assert.Equal(t, "bad field", string(sb.Written(inner)))
+	assert.NoError(t, sb.Rollback(inner))
+	assert.Equal(t, "header;record1;", sb.String())
+	assert.Equal(t, "", string(sb.Written(inner)))
+
+	// rollback to outer checkpoint discards inner
+	sb.WriteString("more")
+	assert.NoError(t, sb.Rollback(outer))
+	assert.Equal(t, "header;", sb.String())
+	assert.Equal(t, ErrOutOfRange, sb.Rollback(inner))
+	assert.Nil(t, sb.Written(inner))
+	assert.Equal(t, "header;", sb.String())
+
+	// string view before the mark is not corrupted by writes after rollback (even with realloc)
+	sb.WriteString("record2; with many bytes for realloc Builder buffer")
+	assert.Equal(t, "header;", header)
+	assert.Equal(t, "header;record2; with many bytes for realloc Builder buffer", sb.String())
+
+	// rollback to the zero checkpoint
+	assert.NoError(t, sb.Rollback(Checkpoint{}))
+	assert.Equal(t, "", sb.String())
+	assert.Equal(t, ErrOutOfRange, sb.Rollback(outer))
+}
+
+func TestBuilder_MarkRollback_Allocs(t *testing.T) {
+	var sb Builder
+	sb.Grow(64)
+	allocs := testing.AllocsPerRun(100, func() {
+		sb.Reset()
+		cp := sb.Mark()
+		sb.WriteString("speculative")
+		_ = sb.Rollback(cp)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
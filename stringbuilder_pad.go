@@ -0,0 +1,106 @@
+package stringutils
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// WriteRepeat appends n copies of the string s (grow the buffer once).
+func (sb *Builder) WriteRepeat(s string, n int) {
+	if n <= 0 || len(s) == 0 {
+		return
+	}
+	pos := sb.extend(len(s) * n)
+	b := sb.data[pos:]
+	// fill by doubling copied part, like strings.Repeat
+	bp := copy(b, s)
+	for bp < len(b) {
+		bp += copy(b[bp:], b[:bp])
+	}
+}
+
+// fill appends n copies of pad rune to already extended buffer at pos, return position after written runes.
+func (sb *Builder) fill(pos int, pad rune, n int) int {
+	if n <= 0 {
+		return pos
+	}
+	b := sb.data[pos:]
+	var w int
+	if pad < utf8.RuneSelf {
+		b[0] = byte(pad)
+		w = 1
+	} else {
+		w = utf8.EncodeRune(b, pad)
+	}
+	size := w * n
+	for bp := w; bp < size; {
+		bp += copy(b[bp:size], b[:bp])
+	}
+	return pos + size
+}
+
+// writePadded appends left pad runes, s and right pad runes (grow the buffer once).
+func (sb *Builder) writePadded(s string, pad rune, left, right int) {
+	w := utf8.RuneLen(pad)
+	if w < 0 {
+		pad = utf8.RuneError
+		w = utf8.RuneLen(pad)
+	}
+	pos := sb.extend((left+right)*w + len(s))
+	pos = sb.fill(pos, pad, left)
+	pos += copy(sb.data[pos:], s)
+	sb.fill(pos, pad, right)
+}
+
+// WritePadLeft appends s, padded on the left with pad rune to width (counted in runes). s is not truncated.
+func (sb *Builder) WritePadLeft(s string, width int, pad rune) {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		sb.WriteString(s)
+		return
+	}
+	sb.writePadded(s, pad, n, 0)
+}
+
+// WritePadRight appends s, padded on the right with pad rune to width (counted in runes). s is not truncated.
+func (sb *Builder) WritePadRight(s string, width int, pad rune) {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		sb.WriteString(s)
+		return
+	}
+	sb.writePadded(s, pad, 0, n)
+}
+
+// WriteCenter appends s, centered with pad rune to width (counted in runes, extra pad rune is on the right). s is not truncated.
+func (sb *Builder) WriteCenter(s string, width int, pad rune) {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		sb.WriteString(s)
+		return
+	}
+	sb.writePadded(s, pad, n/2, n-n/2)
+}
+
+// WriteIntPadded appends the decimal form of the integer i, padded on the left with pad byte to width.
+// With '0' pad the sign is written before padding (-0042).
+func (sb *Builder) WriteIntPadded(i int64, width int, pad byte) {
+	var buf [20]byte
+	num := strconv.AppendInt(buf[:0], i, 10)
+	n := width - len(num)
+	if n <= 0 {
+		sb.WriteBytes(num)
+		return
+	}
+	pos := sb.extend(width)
+	b := sb.data[pos:]
+	if pad == '0' && i < 0 {
+		b[0] = '-'
+		b = b[1:]
+		num = num[1:]
+	}
+	for j := 0; j < n; j++ {
+		b[j] = pad
+	}
+	copy(b[n:], num)
+}
@@ -0,0 +1,120 @@
+package stringutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBuilder_WriteRepeat(t *testing.T) {
+	tests := []struct {
+		s string
+		n int
+	}{
+		{"", 5},
+		{"-", 0},
+		{"-", -1},
+		{"-", 1},
+		{"-", 17},
+		{"ab", 3},
+		{"тест", 5},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.s+" "+strconv.Itoa(tt.n), func(t *testing.T) {
+			sb.Reset()
+			sb.WriteString("|")
+			sb.WriteRepeat(tt.s, tt.n)
+			want := "|"
+			if tt.n > 0 {
+				want += strings.Repeat(tt.s, tt.n)
+			}
+			if sb.String() != want {
+				t.Errorf("WriteRepeat(%q, %d) = %q, want %q", tt.s, tt.n, sb.String(), want)
+			}
+		})
+	}
+}
+
+func TestBuilder_WritePad(t *testing.T) {
+	tests := []struct {
+		s         string
+		width     int
+		pad       rune
+		wantLeft  string
+		wantRight string
+		wantCent  string
+	}{
+		{"", 0, ' ', "", "", ""},
+		{"abc", 2, ' ', "abc", "abc", "abc"},
+		{"abc", 3, ' ', "abc", "abc", "abc"},
+		{"abc", 6, ' ', "   abc", "abc   ", " abc  "},
+		{"abc", 7, '.', "....abc", "abc....", "..abc.."},
+		{"тест", 6, '·', "··тест", "тест··", "·тест·"},
+		{"", 3, '世', "世世世", "世世世", "世世世"},
+		{"ab", 4, -1, "\ufffd\ufffdab", "ab\ufffd\ufffd", "\ufffdab\ufffd"},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.s+" "+strconv.Itoa(tt.width), func(t *testing.T) {
+			sb.Reset()
+			sb.WritePadLeft(tt.s, tt.width, tt.pad)
+			if sb.String() != tt.wantLeft {
+				t.Errorf("WritePadLeft() = %q, want %q", sb.String(), tt.wantLeft)
+			}
+			sb.Reset()
+			sb.WritePadRight(tt.s, tt.width, tt.pad)
+			if sb.String() != tt.wantRight {
+				t.Errorf("WritePadRight() = %q, want %q", sb.String(), tt.wantRight)
+			}
+			sb.Reset()
+			sb.WriteCenter(tt.s, tt.width, tt.pad)
+			if sb.String() != tt.wantCent {
+				t.Errorf("WriteCenter() = %q, want %q", sb.String(), tt.wantCent)
+			}
+		})
+	}
+}
+
+func TestBuilder_WriteIntPadded(t *testing.T) {
+	tests := []struct {
+		i     int64
+		width int
+		pad   byte
+		want  string
+	}{
+		{42, 5, '0', "00042"},
+		{-42, 5, '0', "-0042"},
+		{42, 5, ' ', "   42"},
+		{-42, 5, ' ', "  -42"},
+		{12345, 3, '0', "12345"},
+		{0, 1, '0', "0"},
+		{math.MinInt64, 25, '0', fmt.Sprintf("%025d", int64(math.MinInt64))},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sb.Reset()
+			sb.WriteIntPadded(tt.i, tt.width, tt.pad)
+			if sb.String() != tt.want {
+				t.Errorf("WriteIntPadded(%d, %d, %q) = %q, want %q", tt.i, tt.width, tt.pad, sb.String(), tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkThis_Builder_WritePadLeft(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WritePadLeft("metric", 20, ' ')
+		sb.WriteIntPadded(int64(i), 12, '0')
+	}
+}
@@ -0,0 +1,84 @@
+package stringutils
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// BuilderPoolStats is a snapshot of BuilderPool counters.
+type BuilderPoolStats struct {
+	Hits      uint64 // Get returned a pooled Builder
+	Misses    uint64 // Get allocated a new Builder
+	Discarded uint64 // Put dropped a Builder with capacity above the limit
+}
+
+// BuilderPool is a sync.Pool-backed pool of Builder.
+// Builders with capacity above the limit are released on Put, so one huge render doesn't pin memory forever.
+type BuilderPool struct {
+	// counters first for 64-bit atomic alignment on 32-bit platforms
+	hits      uint64
+	misses    uint64
+	discarded uint64
+
+	initCap int
+	maxCap  int
+	pool    sync.Pool
+}
+
+// NewBuilderPool return new BuilderPool.
+//
+// @initCap Initial capacity for new allocated Builder (0 for no preallocation)
+//
+// @maxCap Maximum capacity of Builder retained in pool (0 for no limit)
+func NewBuilderPool(initCap, maxCap int) *BuilderPool {
+	if initCap < 0 {
+		initCap = 0
+	}
+	if maxCap > 0 && initCap > maxCap {
+		initCap = maxCap
+	}
+	return &BuilderPool{initCap: initCap, maxCap: maxCap}
+}
+
+// Get return empty Builder from pool (or allocate new, if pool is empty).
+func (p *BuilderPool) Get() *Builder {
+	if v := p.pool.Get(); v != nil {
+		atomic.AddUint64(&p.hits, 1)
+		return v.(*Builder)
+	}
+	atomic.AddUint64(&p.misses, 1)
+	sb := new(Builder)
+	if p.initCap > 0 {
+		sb.Grow(p.initCap)
+	}
+	return sb
+}
+
+// Put reset Builder and return it to pool. Builder with capacity above the limit is released and discarded.
+// Do not use Builder (and strings, returned by Builder.String) after Put.
+func (p *BuilderPool) Put(sb *Builder) {
+	if sb == nil {
+		return
+	}
+	if p.maxCap > 0 && sb.Cap() > p.maxCap {
+		atomic.AddUint64(&p.discarded, 1)
+		sb.Release()
+		return
+	}
+	sb.Reset()
+	p.pool.Put(sb)
+}
+
+// Stats return pool counters.
+func (p *BuilderPool) Stats() BuilderPoolStats {
+	return BuilderPoolStats{
+		Hits:      atomic.LoadUint64(&p.hits),
+		Misses:    atomic.LoadUint64(&p.misses),
+		Discarded: atomic.LoadUint64(&p.discarded),
+	}
+}
+
+// MaxCap return maximum capacity of Builder retained in pool (0 for no limit).
+func (p *BuilderPool) MaxCap() int {
+	return p.maxCap
+}
@@ -0,0 +1,65 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderPool(t *testing.T) {
+	p := NewBuilderPool(16, 64)
+
+	sb := p.Get()
+	assert.Equal(t, 0, sb.Len())
+	assert.Equal(t, 16, sb.Cap())
+	sb.WriteString("hello")
+	p.Put(sb)
+
+	// pooled or new builder must be empty
+	sb = p.Get()
+	assert.Equal(t, 0, sb.Len())
+	assert.Equal(t, "", sb.String())
+
+	// oversized builder is discarded
+	sb.WriteString(strings.Repeat("a", 128))
+	p.Put(sb)
+	assert.Equal(t, 0, sb.Cap())
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(2), stats.Hits+stats.Misses)
+	assert.Equal(t, uint64(1), stats.Discarded)
+
+	p.Put(nil)
+	assert.Equal(t, uint64(1), p.Stats().Discarded)
+}
+
+func TestBuilderPool_Unlimited(t *testing.T) {
+	p := NewBuilderPool(0, 0)
+
+	sb := p.Get()
+	assert.Equal(t, 0, sb.Cap())
+	sb.WriteString(strings.Repeat("a", 1024))
+	p.Put(sb)
+
+	assert.Equal(t, 0, p.MaxCap())
+	assert.Equal(t, uint64(0), p.Stats().Discarded)
+}
+
+func BenchmarkThis_BuilderPool(b *testing.B) {
+	p := NewBuilderPool(128, 4096)
+	s := "asdfghjklqwertyuiopzxcvbnm1234567890"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			sb := p.Get()
+			sb.WriteString(s)
+			sb.WriteInt(1234567, 10)
+			_ = sb.String()
+			p.Put(sb)
+		}
+	})
+}
@@ -0,0 +1,477 @@
+package stringutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	hexDigitsUpper = "0123456789ABCDEF"
+	printfMaxNum   = 1e6 // maximum width and precision (as in fmt)
+)
+
+// printfSpec is a parsed Printf verb spec (flags, width and precision)
+type printfSpec struct {
+	minus bool // '-'
+	plus  bool // '+'
+	sharp bool // '#'
+	space bool // ' '
+	zero  bool // '0'
+	width int  // -1 if absent
+	prec  int  // -1 if absent
+}
+
+// Printf appends formatted string like fmt.Fprintf, but without reflection and allocations for practical subset of verbs.
+//
+// Supported verbs: %s %d %x %X %o %b %f %F %e %E %g %G %q %v %t %% with width, precision and '-', '+', ' ', '0' flags
+// for strings, []byte, integers, floats, bools, errors and fmt.Stringer.
+// Other verbs, flags and types are formatted with fmt. Explicit argument indexes are not supported.
+func (sb *Builder) Printf(format string, args ...interface{}) {
+	argNum := 0
+	end := len(format)
+	for i := 0; i < end; {
+		lasti := i
+		for i < end && format[i] != '%' {
+			i++
+		}
+		if i > lasti {
+			sb.WriteString(format[lasti:i])
+		}
+		if i >= end {
+			break
+		}
+		// skip %
+		i++
+
+		spec := printfSpec{width: -1, prec: -1}
+	flags:
+		for ; i < end; i++ {
+			switch format[i] {
+			case '-':
+				spec.minus = true
+				spec.zero = false // Do not pad with zeros to the right.
+			case '+':
+				spec.plus = true
+			case '#':
+				spec.sharp = true
+			case ' ':
+				spec.space = true
+			case '0':
+				spec.zero = !spec.minus // Only allow zero padding to the left.
+			default:
+				break flags
+			}
+		}
+
+		// width
+		if i < end && format[i] == '*' {
+			i++
+			var ok bool
+			spec.width, ok, argNum = printfIntArg(args, argNum)
+			if !ok {
+				sb.WriteString("%!(BADWIDTH)")
+			}
+			if spec.width < 0 {
+				spec.width = -spec.width
+				spec.minus = true
+				spec.zero = false
+			}
+		} else {
+			spec.width, i = printfParseNum(format, i)
+		}
+
+		// precision
+		if i < end && format[i] == '.' {
+			i++
+			if i < end && format[i] == '*' {
+				i++
+				var ok bool
+				spec.prec, ok, argNum = printfIntArg(args, argNum)
+				if spec.prec < 0 {
+					// negative precision argument is bad, as in fmt
+					spec.prec, ok = -1, false
+				}
+				if !ok {
+					sb.WriteString("%!(BADPREC)")
+				}
+			} else if spec.prec, i = printfParseNum(format, i); spec.prec < 0 {
+				// "%.s" means zero precision
+				spec.prec = 0
+			}
+		}
+
+		if i >= end {
+			sb.WriteString("%!(NOVERB)")
+			break
+		}
+
+		verb, size := rune(format[i]), 1
+		if verb >= utf8.RuneSelf {
+			verb, size = utf8.DecodeRuneInString(format[i:])
+		}
+		i += size
+
+		switch {
+		case verb == '%':
+			_ = sb.WriteByte('%')
+		case argNum >= len(args):
+			sb.WriteString("%!")
+			_, _ = sb.WriteRune(verb)
+			sb.WriteString("(MISSING)")
+		default:
+			sb.printArg(args[argNum], verb, &spec)
+			argNum++
+		}
+	}
+
+	if argNum < len(args) {
+		sb.WriteString("%!(EXTRA ")
+		for i, arg := range args[argNum:] {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if arg == nil {
+				sb.WriteString("<nil>")
+			} else {
+				fmt.Fprintf(sb, "%T=%v", arg, arg)
+			}
+		}
+		_ = sb.WriteByte(')')
+	}
+}
+
+// printfParseNum parse decimal number at format[start:], return -1 if no digits found.
+func printfParseNum(format string, start int) (num int, end int) {
+	num = -1
+	for end = start; end < len(format) && '0' <= format[end] && format[end] <= '9'; end++ {
+		if num < 0 {
+			num = 0
+		}
+		if num < printfMaxNum {
+			num = num*10 + int(format[end]-'0')
+		}
+	}
+	return
+}
+
+// printfIntArg return integer argument for '*' width or precision.
+func printfIntArg(args []interface{}, argNum int) (num int, ok bool, newArgNum int) {
+	newArgNum = argNum
+	if argNum < len(args) {
+		newArgNum++
+		switch v := args[argNum].(type) {
+		case int:
+			num, ok = v, true
+		case int8:
+			num, ok = int(v), true
+		case int16:
+			num, ok = int(v), true
+		case int32:
+			num, ok = int(v), true
+		case int64:
+			num, ok = int(v), true
+		case uint:
+			num, ok = int(v), true
+		case uint8:
+			num, ok = int(v), true
+		case uint16:
+			num, ok = int(v), true
+		case uint32:
+			num, ok = int(v), true
+		case uint64:
+			num, ok = int(v), true
+		}
+		if num > printfMaxNum || num < -printfMaxNum {
+			num, ok = 0, false
+		}
+	}
+	return
+}
+
+func (sb *Builder) printArg(arg interface{}, verb rune, spec *printfSpec) {
+	if spec.sharp {
+		sb.printFallback(arg, verb, spec)
+		return
+	}
+	// fmt use '+' with %v as plusV flag (field names for structs), so it's not a sign for numbers
+	plusV := verb == 'v' && spec.plus
+	if plusV {
+		spec.plus = false
+	}
+	start := sb.Len()
+	var ok, number bool
+	switch v := arg.(type) {
+	case string:
+		ok = sb.printString(v, verb, spec)
+	case []byte:
+		// %v and %d print []byte as slice of numbers
+		if verb == 's' || verb == 'q' || verb == 'x' || verb == 'X' {
+			ok = sb.printString(UnsafeString(v), verb, spec)
+		}
+	case int:
+		ok, number = sb.printInt(int64(v), verb, spec), true
+	case int8:
+		ok, number = sb.printInt(int64(v), verb, spec), true
+	case int16:
+		ok, number = sb.printInt(int64(v), verb, spec), true
+	case int32:
+		ok, number = sb.printInt(int64(v), verb, spec), true
+	case int64:
+		ok, number = sb.printInt(v, verb, spec), true
+	case uint:
+		ok, number = sb.printUint(uint64(v), false, verb, spec), true
+	case uint8:
+		ok, number = sb.printUint(uint64(v), false, verb, spec), true
+	case uint16:
+		ok, number = sb.printUint(uint64(v), false, verb, spec), true
+	case uint32:
+		ok, number = sb.printUint(uint64(v), false, verb, spec), true
+	case uint64:
+		ok, number = sb.printUint(v, false, verb, spec), true
+	case float64:
+		ok, number = sb.printFloat(v, 64, verb, spec), true
+	case float32:
+		ok, number = sb.printFloat(float64(v), 32, verb, spec), true
+	case bool:
+		if ok = verb == 't' || verb == 'v'; ok {
+			sb.WriteBool(v)
+		}
+	case error:
+		ok = sb.printMethod(v.Error, verb, spec)
+	case fmt.Stringer:
+		ok = sb.printMethod(v.String, verb, spec)
+	}
+
+	if ok && !(spec.zero && !number) {
+		sb.printPad(start, spec, number)
+	} else {
+		sb.Truncate(start)
+		spec.plus = spec.plus || plusV
+		sb.printFallback(arg, verb, spec)
+	}
+}
+
+// printMethod print result of Error or String method (fallback to fmt on panic, like with nil receiver).
+func (sb *Builder) printMethod(method func() string, verb rune, spec *printfSpec) (ok bool) {
+	switch verb {
+	case 's', 'v', 'q':
+		defer func() {
+			if recover() != nil {
+				ok = false
+			}
+		}()
+		return sb.printString(method(), verb, spec)
+	default:
+		return false
+	}
+}
+
+// printFallback format arg with fmt.
+func (sb *Builder) printFallback(arg interface{}, verb rune, spec *printfSpec) {
+	var buf [32]byte
+	f := append(buf[:0], '%')
+	if spec.minus {
+		f = append(f, '-')
+	}
+	if spec.plus {
+		f = append(f, '+')
+	}
+	if spec.sharp {
+		f = append(f, '#')
+	}
+	if spec.space {
+		f = append(f, ' ')
+	}
+	if spec.zero {
+		f = append(f, '0')
+	}
+	if spec.width >= 0 {
+		f = strconv.AppendInt(f, int64(spec.width), 10)
+	}
+	if spec.prec >= 0 {
+		f = append(f, '.')
+		f = strconv.AppendInt(f, int64(spec.prec), 10)
+	}
+	var r [utf8.UTFMax]byte
+	n := utf8.EncodeRune(r[:], verb)
+	f = append(f, r[:n]...)
+
+	fmt.Fprintf(sb, string(f), arg)
+}
+
+// printPad pad value, written from start, to spec.width (in runes).
+func (sb *Builder) printPad(start int, spec *printfSpec, number bool) {
+	if spec.width <= 0 {
+		return
+	}
+	n := spec.width - utf8.RuneCount(sb.data[start:])
+	if n <= 0 {
+		return
+	}
+	if spec.minus {
+		pos := sb.extend(n)
+		for i := pos; i < len(sb.data); i++ {
+			sb.data[i] = ' '
+		}
+		return
+	}
+	end := sb.Len()
+	sb.extend(n)
+	copy(sb.data[start+n:], sb.data[start:end])
+	padChar := byte(' ')
+	if spec.zero && number {
+		padChar = '0'
+		// zero padding after sign
+		if c := sb.data[start+n]; c == '-' || c == '+' || c == ' ' {
+			sb.data[start] = c
+			start++
+		}
+	}
+	for i := start; i < start+n; i++ {
+		sb.data[i] = padChar
+	}
+}
+
+// truncateRunes truncate s to n runes (if n >= 0).
+func truncateRunes(s string, n int) string {
+	if n < 0 {
+		return s
+	}
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+func (sb *Builder) printString(s string, verb rune, spec *printfSpec) bool {
+	switch verb {
+	case 's', 'v':
+		sb.WriteString(truncateRunes(s, spec.prec))
+	case 'q':
+		if spec.plus {
+			sb.WriteQuoteToASCII(truncateRunes(s, spec.prec))
+		} else {
+			sb.WriteQuote(truncateRunes(s, spec.prec))
+		}
+	case 'x', 'X':
+		if spec.space {
+			return false
+		}
+		if spec.prec >= 0 && spec.prec < len(s) {
+			s = s[:spec.prec]
+		}
+		digits := hexDigits
+		if verb == 'X' {
+			digits = hexDigitsUpper
+		}
+		pos := sb.extend(2 * len(s))
+		b := sb.data[pos:]
+		for i := 0; i < len(s); i++ {
+			b[2*i] = digits[s[i]>>4]
+			b[2*i+1] = digits[s[i]&0xF]
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func printfBase(verb rune) int {
+	switch verb {
+	case 'd', 'v':
+		return 10
+	case 'x', 'X':
+		return 16
+	case 'o':
+		return 8
+	case 'b':
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (sb *Builder) printInt(v int64, verb rune, spec *printfSpec) bool {
+	if v < 0 {
+		if spec.prec >= 0 {
+			return false
+		}
+		base := printfBase(verb)
+		if base == 0 {
+			return false
+		}
+		_ = sb.WriteByte('-')
+		return sb.printUint(-uint64(v), true, verb, spec)
+	}
+	return sb.printUint(uint64(v), false, verb, spec)
+}
+
+func (sb *Builder) printUint(v uint64, signed bool, verb rune, spec *printfSpec) bool {
+	if spec.prec >= 0 {
+		return false
+	}
+	base := printfBase(verb)
+	if base == 0 {
+		return false
+	}
+	if !signed {
+		if spec.plus {
+			_ = sb.WriteByte('+')
+		} else if spec.space {
+			_ = sb.WriteByte(' ')
+		}
+	}
+	start := sb.Len()
+	sb.WriteUint(v, base)
+	if verb == 'X' {
+		ToUpperBytes(sb.data[start:])
+	}
+	return true
+}
+
+func (sb *Builder) printFloat(v float64, bitSize int, verb rune, spec *printfSpec) bool {
+	var (
+		format byte
+		prec   = spec.prec
+	)
+	switch verb {
+	case 'v', 'g':
+		format = 'g'
+	case 'G':
+		format = 'G'
+	case 'f', 'F':
+		format = 'f'
+		if prec < 0 {
+			prec = 6
+		}
+	case 'e', 'E':
+		format = byte(verb)
+		if prec < 0 {
+			prec = 6
+		}
+	default:
+		return false
+	}
+	if v-v != 0 {
+		// NaN or Inf
+		if spec.plus || spec.space || spec.zero || spec.width > 0 {
+			return false
+		}
+		sb.WriteFloat(v, format, prec, bitSize)
+		return true
+	}
+	if !math.Signbit(v) {
+		if spec.plus {
+			_ = sb.WriteByte('+')
+		} else if spec.space {
+			_ = sb.WriteByte(' ')
+		}
+	}
+	sb.WriteFloat(v, format, prec, bitSize)
+	return true
+}
@@ -0,0 +1,90 @@
+package stringutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+type printfStringer struct{ s string }
+
+func (p *printfStringer) String() string { return p.s }
+
+func TestBuilder_Printf(t *testing.T) {
+	var nilStringer *printfStringer
+
+	tests := []struct {
+		format string
+		args   []interface{}
+	}{
+		{"", nil},
+		{"plain text", nil},
+		{"100%%", nil},
+		{"%s %v %q", []interface{}{"str", "тест", "a\"b\n"}},
+		{"[%5s] [%-5s] [%.2s] [%5.1s] [%.0s]", []interface{}{"ab", "ab", "тест", "тест", "abc"}},
+		{"%x %X %q %+q %s", []interface{}{"hi\xff", []byte("hi"), "мир", "мир", []byte("bytes")}},
+		{"%d %v %x %X %o %b", []interface{}{42, -42, 255, -255, 8, 5}},
+		{"%d %d %d %d %d", []interface{}{int8(-8), int16(16), int32(-32), int64(math.MinInt64), uint64(math.MaxUint64)}},
+		{"%d %d %d %d", []interface{}{uint(1), uint8(8), uint16(16), uint32(32)}},
+		{"[%5d] [%-5d] [%05d] [%+d] [% d] [%+05d] [%-05d]", []interface{}{42, 42, -42, 42, 42, 42, 42}},
+		{"%f %.2f %e %E %g %G %v %v", []interface{}{1.5, 3.14159, 123456.789, 0.000123, 1e21, 1e-7, 2.5, float32(0.1)}},
+		{"[%8.3f] [%-8.2f] [%08.3f] [%+.1f] [% .1f] [%+v]", []interface{}{3.14159, 2.5, -3.14159, 2.0, 2.0, math.Copysign(0, -1)}},
+		{"%v %v %v %f %5v %+v", []interface{}{math.NaN(), math.Inf(1), math.Inf(-1), math.NaN(), math.Inf(1), math.NaN()}},
+		{"%t %v [%6t]", []interface{}{true, false, true}},
+		{"%v %s %q [%10v]", []interface{}{errors.New("err"), time.Second, &printfStringer{"x"}, time.Millisecond}},
+		{"%v %s", []interface{}{nilStringer, nil}},
+		{"%*d|%-*d|%.*f", []interface{}{5, 1, 5, 2, 2, 3.14159}},
+		{"%.*s|%.*d|%*d", []interface{}{-1, "abc", -2, 5, -3, 7}},
+		{"%+v %+v %+v %+5v [%+ v] %+v %+v %+v", []interface{}{42, uint8(7), 2.5, -3, 4, float32(1), "s", struct{ A int }{1}}},
+		{"%#x %#v %c %U %08s %.3d", []interface{}{255, "s", 'ф', 'ф', "ab", 7}},
+		{"%d %s", []interface{}{"str", 1}},
+		{"%v %v", []interface{}{[]int{1, 2}, map[string]int{"a": 1}}},
+		{"%v %v %d %s", []interface{}{[]byte("ab"), []byte(nil), []byte("ab"), []byte(nil)}},
+		{"%d %d", []interface{}{1}},
+		{"%d", []interface{}{1, "extra", nil}},
+		{"%z %!", []interface{}{1, 2}},
+		{"%", nil},
+		{"%5", nil},
+		{"%ф", []interface{}{1}},
+	}
+	var sb Builder
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			sb.Reset()
+			sb.WriteString("> ")
+			sb.Printf(tt.format, tt.args...)
+			want := "> " + fmt.Sprintf(tt.format, tt.args...)
+			if sb.String() != want {
+				t.Errorf("Printf(%q) = %q, want %q", tt.format, sb.String(), want)
+			}
+		})
+	}
+}
+
+func BenchmarkThis_Builder_Printf(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.Printf("%s.%s %8.3f %d", "test", "metric", 12.5, 1667464245)
+	}
+}
+
+func BenchmarkStd_Fmt_Sprintf(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteString(fmt.Sprintf("%s.%s %8.3f %d", "test", "metric", 12.5, 1667464245))
+	}
+}
@@ -1,11 +1,15 @@
 package stringutils
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
 	"math"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestBuilder_Grow(t *testing.T) {
@@ -406,6 +410,119 @@ func TestBuilder(t *testing.T) {
 	}
 }
 
+func TestBuilder_ReadFrom(t *testing.T) {
+	s := strings.Repeat("asdfghjklqwertyuiopzxcvbnm1234567890", 100)
+
+	var sb Builder
+	sb.WriteString("prefix:")
+	n, err := sb.ReadFrom(iotest.OneByteReader(strings.NewReader(s)))
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if n != int64(len(s)) {
+		t.Errorf("ReadFrom() = %d, want %d", n, len(s))
+	}
+	if sb.String() != "prefix:"+s {
+		t.Errorf("String() = '%s', want '%s'", sb.String(), "prefix:"+s)
+	}
+
+	// io.Copy use ReadFrom
+	sb.Reset()
+	if _, err = io.Copy(&sb, strings.NewReader(s)); err != nil {
+		t.Fatalf("io.Copy() error = %v", err)
+	}
+	if sb.String() != s {
+		t.Errorf("String() = '%s', want '%s'", sb.String(), s)
+	}
+
+	// error
+	sb.Reset()
+	n, err = sb.ReadFrom(iotest.TimeoutReader(iotest.HalfReader(strings.NewReader(s))))
+	if err != iotest.ErrTimeout {
+		t.Errorf("ReadFrom() error = %v, want %v", err, iotest.ErrTimeout)
+	}
+	if n != int64(sb.Len()) || sb.String() != s[:n] {
+		t.Errorf("ReadFrom() = %d, String() = '%s'", n, sb.String())
+	}
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	return len(p) / 2, nil
+}
+
+func TestBuilder_WriteTo(t *testing.T) {
+	var (
+		sb  Builder
+		buf bytes.Buffer
+	)
+	n, err := sb.WriteTo(&buf)
+	if n != 0 || err != nil {
+		t.Errorf("WriteTo() = (%d, %v), want (0, nil)", n, err)
+	}
+
+	sb.WriteString("hello 世界")
+	if _, err = sb.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if buf.String() != "hello 世界" {
+		t.Errorf("WriteTo() = '%s', want '%s'", buf.String(), "hello 世界")
+	}
+	if sb.String() != "hello 世界" {
+		t.Errorf("String() = '%s', want '%s'", sb.String(), "hello 世界")
+	}
+
+	n, err = sb.WriteTo(shortWriter{})
+	if n != int64(sb.Len()/2) || err != io.ErrShortWrite {
+		t.Errorf("WriteTo() = (%d, %v), want (%d, %v)", n, err, sb.Len()/2, io.ErrShortWrite)
+	}
+}
+
+func TestBuilder_NewReader(t *testing.T) {
+	var sb Builder
+	sb.WriteString("hello 世界")
+
+	b, err := ioutil.ReadAll(sb.NewReader())
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(b) != sb.String() {
+		t.Errorf("NewReader() read '%s', want '%s'", string(b), sb.String())
+	}
+}
+
+func TestBuilder_TruncateUTF8(t *testing.T) {
+	tests := []struct {
+		s      string
+		length int
+		want   string
+	}{
+		{s: "hello", length: 10, want: "hello"},
+		{s: "hello", length: 3, want: "hel"},
+		{s: "hello", length: -1, want: ""},
+		{s: "aмир", length: 1, want: "a"},
+		{s: "aмир", length: 2, want: "a"},
+		{s: "aмир", length: 3, want: "aм"},
+		{s: "世界", length: 1, want: ""},
+		{s: "世界", length: 2, want: ""},
+		{s: "世界", length: 5, want: "世"},
+		{s: "a\U0001F600", length: 4, want: "a"},
+		{s: "ab\x80\x80\x80\x80c", length: 5, want: "ab\x80\x80\x80"},
+		{s: "a\xe4\xb8", length: 2, want: "a\xe4"},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.s)+"#"+strconv.Itoa(tt.length), func(t *testing.T) {
+			var sb Builder
+			sb.WriteString(tt.s)
+			sb.TruncateUTF8(tt.length)
+			if sb.String() != tt.want {
+				t.Errorf("TruncateUTF8(%d) = %q, want %q", tt.length, sb.String(), tt.want)
+			}
+		})
+	}
+}
+
 func Benchmark_String_RawCopy(b *testing.B) {
 	buf := make([]byte, 1000000)
 	pos := 0
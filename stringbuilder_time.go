@@ -0,0 +1,230 @@
+package stringutils
+
+import (
+	"time"
+)
+
+// Special layouts for WriteTime (integer Unix timestamps)
+const (
+	TimeUnix      = "unix"      // Unix time, the number of seconds elapsed since January 1, 1970 UTC
+	TimeUnixMilli = "unixmilli" // Unix time in milliseconds
+	TimeUnixMicro = "unixmicro" // Unix time in microseconds
+	TimeUnixNano  = "unixnano"  // Unix time in nanoseconds
+)
+
+const digits10 = "0123456789"
+
+// WriteTime appends the textual representation of t, as generated by time.Format (in-place, without allocations).
+// RFC3339 and RFC3339Nano layouts has fast path. Also TimeUnix, TimeUnixMilli, TimeUnixMicro and TimeUnixNano special layouts supported.
+func (sb *Builder) WriteTime(t time.Time, layout string) {
+	switch layout {
+	case time.RFC3339:
+		sb.writeRFC3339(t, false)
+	case time.RFC3339Nano:
+		sb.writeRFC3339(t, true)
+	case TimeUnix:
+		sb.WriteInt(t.Unix(), 10)
+	case TimeUnixMilli:
+		// UnixNano overflows outside of years 1678-2262
+		sb.WriteInt(t.Unix()*1e3+int64(t.Nanosecond())/1e6, 10)
+	case TimeUnixMicro:
+		sb.WriteInt(t.Unix()*1e6+int64(t.Nanosecond())/1e3, 10)
+	case TimeUnixNano:
+		sb.WriteInt(t.UnixNano(), 10)
+	default:
+		sb.data = t.AppendFormat(sb.data, layout)
+	}
+}
+
+func (sb *Builder) writeRFC3339(t time.Time, nano bool) {
+	year, month, day := t.Date()
+	if year < 0 || year > 9999 {
+		if nano {
+			sb.data = t.AppendFormat(sb.data, time.RFC3339Nano)
+		} else {
+			sb.data = t.AppendFormat(sb.data, time.RFC3339)
+		}
+		return
+	}
+	hour, min, sec := t.Clock()
+
+	// 2006-01-02T15:04:05
+	pos := sb.extend(19)
+	b := sb.data[pos:]
+	b[0] = digits10[year/1000]
+	b[1] = digits10[year/100%10]
+	b[2] = digits10[year/10%10]
+	b[3] = digits10[year%10]
+	b[4] = '-'
+	put2Digits(b[5:], int(month))
+	b[7] = '-'
+	put2Digits(b[8:], day)
+	b[10] = 'T'
+	put2Digits(b[11:], hour)
+	b[13] = ':'
+	put2Digits(b[14:], min)
+	b[16] = ':'
+	put2Digits(b[17:], sec)
+
+	if nano {
+		if ns := t.Nanosecond(); ns > 0 {
+			// .999999999 with trailing zeros removed
+			n := 9
+			for ns%10 == 0 {
+				ns /= 10
+				n--
+			}
+			pos = sb.extend(n + 1)
+			b = sb.data[pos:]
+			b[0] = '.'
+			for i := n; i > 0; i-- {
+				b[i] = digits10[ns%10]
+				ns /= 10
+			}
+		}
+	}
+
+	// Z07:00
+	_, offset := t.Zone()
+	if offset == 0 {
+		_ = sb.WriteByte('Z')
+		return
+	}
+	pos = sb.extend(6)
+	b = sb.data[pos:]
+	// sign from offset in minutes, like time.Format (offset in (-60s, 0) is +00:00)
+	zone := offset / 60
+	if zone < 0 {
+		b[0] = '-'
+		zone = -zone
+	} else {
+		b[0] = '+'
+	}
+	put2Digits(b[1:], zone/60)
+	b[3] = ':'
+	put2Digits(b[4:], zone%60)
+}
+
+func put2Digits(b []byte, n int) {
+	b[0] = digits10[n/10%10]
+	b[1] = digits10[n%10]
+}
+
+// WriteDuration appends the string form of the duration d, as generated by time.Duration.String (in-place, without allocations).
+func (sb *Builder) WriteDuration(d time.Duration) {
+	// Largest time is 2540400h10m10.000000000s
+	var buf [32]byte
+	w := len(buf)
+
+	u := uint64(d)
+	neg := d < 0
+	if neg {
+		u = -u
+	}
+
+	if u < uint64(time.Second) {
+		// Special case: if duration is smaller than a second,
+		// use smaller units, like 1.2ms
+		var prec int
+		w--
+		buf[w] = 's'
+		w--
+		switch {
+		case u == 0:
+			_ = sb.WriteByte('0')
+			_ = sb.WriteByte('s')
+			return
+		case u < uint64(time.Microsecond):
+			// print nanoseconds
+			prec = 0
+			buf[w] = 'n'
+		case u < uint64(time.Millisecond):
+			// print microseconds
+			prec = 3
+			// U+00B5 'µ' micro sign == 0xC2 0xB5
+			w-- // Need room for two bytes.
+			copy(buf[w:], "µ")
+		default:
+			// print milliseconds
+			prec = 6
+			buf[w] = 'm'
+		}
+		w, u = fmtFrac(buf[:w], u, prec)
+		w = fmtInt(buf[:w], u)
+	} else {
+		w--
+		buf[w] = 's'
+
+		w, u = fmtFrac(buf[:w], u, 9)
+
+		// u is now integer seconds
+		w = fmtInt(buf[:w], u%60)
+		u /= 60
+
+		// u is now integer minutes
+		if u > 0 {
+			w--
+			buf[w] = 'm'
+			w = fmtInt(buf[:w], u%60)
+			u /= 60
+
+			// u is now integer hours
+			// Stop at hours because days can be different lengths.
+			if u > 0 {
+				w--
+				buf[w] = 'h'
+				w = fmtInt(buf[:w], u)
+			}
+		}
+	}
+
+	if neg {
+		w--
+		buf[w] = '-'
+	}
+
+	sb.WriteBytes(buf[w:])
+}
+
+// based on time.fmtFrac
+// fmtFrac formats the fraction of v/10**prec (e.g., ".12345") into the
+// tail of buf, omitting trailing zeros. It omits the decimal
+// point too when the fraction is 0. It returns the index where the
+// output bytes begin and the value v/10**prec.
+func fmtFrac(buf []byte, v uint64, prec int) (nw int, nv uint64) {
+	// Omit trailing zeros up to and including decimal point.
+	w := len(buf)
+	print := false
+	for i := 0; i < prec; i++ {
+		digit := v % 10
+		print = print || digit != 0
+		if print {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+		v /= 10
+	}
+	if print {
+		w--
+		buf[w] = '.'
+	}
+	return w, v
+}
+
+// based on time.fmtInt
+// fmtInt formats v into the tail of buf.
+// It returns the index where the output begins.
+func fmtInt(buf []byte, v uint64) int {
+	w := len(buf)
+	if v == 0 {
+		w--
+		buf[w] = '0'
+	} else {
+		for v > 0 {
+			w--
+			buf[w] = byte(v%10) + '0'
+			v /= 10
+		}
+	}
+	return w
+}
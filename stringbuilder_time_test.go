@@ -0,0 +1,143 @@
+package stringutils
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBuilder_WriteTime(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*3600)
+	nst := time.FixedZone("NST", -(3*3600 + 30*60))
+	lmt := time.FixedZone("LMT", -30)
+	times := []time.Time{
+		time.Date(2023, 11, 3, 15, 4, 5, 0, time.UTC),
+		time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC),
+		time.Date(2023, 1, 2, 3, 4, 5, 120000000, msk),
+		time.Date(1999, 12, 31, 23, 59, 59, 1000, nst),
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(12345, 1, 1, 0, 0, 0, 10, time.UTC),
+		time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1890, 1, 1, 0, 0, 0, 0, lmt),
+	}
+	layouts := []string{time.RFC3339, time.RFC3339Nano, time.RFC1123Z, time.Kitchen, "2006-01-02 15:04:05.000"}
+
+	var sb Builder
+	for _, tm := range times {
+		for _, layout := range layouts {
+			t.Run(tm.String()+" "+layout, func(t *testing.T) {
+				sb.Reset()
+				sb.WriteString("ts=")
+				sb.WriteTime(tm, layout)
+				want := "ts=" + tm.Format(layout)
+				if sb.String() != want {
+					t.Errorf("WriteTime() = '%s', want '%s'", sb.String(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestBuilder_WriteTimeUnix(t *testing.T) {
+	tests := []struct {
+		tm     time.Time
+		layout string
+		want   int64
+	}{
+		{time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC), TimeUnix, 1672628645},
+		{time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC), TimeUnixMilli, 1672628645123},
+		{time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC), TimeUnixMicro, 1672628645123456},
+		{time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC), TimeUnixNano, 1672628645123456789},
+		// before 1970
+		{time.Unix(-1, 999999999), TimeUnix, -1},
+		{time.Unix(-1, 999999999), TimeUnixMilli, -1},
+		{time.Unix(-1, 999999999), TimeUnixMicro, -1},
+		{time.Unix(-1, 999999999), TimeUnixNano, -1},
+		{time.Date(1960, 1, 1, 0, 0, 0, 500000, time.UTC), TimeUnixMilli, -315619200000},
+		{time.Date(1960, 1, 1, 0, 0, 0, 500000, time.UTC), TimeUnixMicro, -315619199999500},
+		// after 2262 (UnixNano overflow)
+		{time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), TimeUnix, 32503680000},
+		{time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), TimeUnixMilli, 32503680000000},
+		{time.Date(3000, 1, 1, 0, 0, 0, 1000, time.UTC), TimeUnixMicro, 32503680000000001},
+		// before 1678 (UnixNano overflow)
+		{time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), TimeUnixMilli, -11676096000000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tm.String()+" "+tt.layout, func(t *testing.T) {
+			var sb Builder
+			sb.WriteTime(tt.tm, tt.layout)
+			if want := strconv.FormatInt(tt.want, 10); sb.String() != want {
+				t.Errorf("WriteTime() = '%s', want '%s'", sb.String(), want)
+			}
+		})
+	}
+}
+
+func TestBuilder_WriteDuration(t *testing.T) {
+	tests := []time.Duration{
+		0,
+		1,
+		-1,
+		1100 * time.Nanosecond,
+		2200 * time.Microsecond,
+		3300 * time.Millisecond,
+		4*time.Minute + 5*time.Second,
+		4*time.Minute + 5001*time.Millisecond,
+		5*time.Hour + 6*time.Minute + 7001*time.Millisecond,
+		8*time.Minute + 1,
+		1<<63 - 1,
+		-1 << 63,
+	}
+	var sb Builder
+	for _, d := range tests {
+		t.Run(d.String(), func(t *testing.T) {
+			sb.Reset()
+			sb.WriteDuration(d)
+			if sb.String() != d.String() {
+				t.Errorf("WriteDuration() = '%s', want '%s'", sb.String(), d.String())
+			}
+		})
+	}
+}
+
+func BenchmarkThis_Builder_WriteTimeRFC3339Nano(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	tm := time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteTime(tm, time.RFC3339Nano)
+	}
+}
+
+func BenchmarkStd_Time_FormatRFC3339Nano(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	tm := time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteString(tm.Format(time.RFC3339Nano))
+	}
+}
+
+func BenchmarkThis_Builder_WriteDuration(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	d := 5*time.Hour + 6*time.Minute + 7001*time.Millisecond
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		sb.WriteDuration(d)
+	}
+}
@@ -0,0 +1,57 @@
+package stringutils
+
+import "encoding/binary"
+
+// SWAR (SIMD within a register) helpers for process 8 ASCII bytes at a time
+
+const (
+	swarLo = 0x0101010101010101
+	swarHi = 0x8080808080808080
+)
+
+// load64 loads 8 bytes of s from position i as little-endian uint64 (compiled to single load).
+func load64(s string, i int) uint64 {
+	_ = s[i+7] // bounds check hint to compiler
+	return uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+		uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+}
+
+// swarRangeMask returns 0x80 in each byte of w in range [lo, hi] (non-ASCII bytes are never in range).
+func swarRangeMask(w uint64, lo, hi byte) uint64 {
+	x := w &^ swarHi // clear high bits, so additions never carry into the next byte
+	ge := x + (0x80-uint64(lo))*swarLo
+	gt := x + (0x80-uint64(hi)-1)*swarLo
+	return ge &^ gt &^ w & swarHi
+}
+
+// swarToLower converts ASCII upper case letters in each byte of w to lower case.
+func swarToLower(w uint64) uint64 {
+	return w | swarRangeMask(w, 'A', 'Z')>>2
+}
+
+// swarToUpper converts ASCII lower case letters in each byte of w to upper case.
+func swarToUpper(w uint64) uint64 {
+	return w &^ (swarRangeMask(w, 'a', 'z') >> 2)
+}
+
+// toLowerString copy s to dst (with same length) in lower case.
+func toLowerString(dst []byte, s string) {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		binary.LittleEndian.PutUint64(dst[i:], swarToLower(load64(s, i)))
+	}
+	for ; i < len(s); i++ {
+		dst[i] = toLowerTable[s[i]]
+	}
+}
+
+// toUpperString copy s to dst (with same length) in upper case.
+func toUpperString(dst []byte, s string) {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		binary.LittleEndian.PutUint64(dst[i:], swarToUpper(load64(s, i)))
+	}
+	for ; i < len(s); i++ {
+		dst[i] = toUpperTable[s[i]]
+	}
+}
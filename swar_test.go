@@ -0,0 +1,228 @@
+package stringutils
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// byte-by-byte table implementations for compare with SWAR
+
+func toLowerByTable(s string) string {
+	res := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		res[i] = toLowerTable[s[i]]
+	}
+	return UnsafeString(res)
+}
+
+func toUpperByTable(s string) string {
+	res := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		res[i] = toUpperTable[s[i]]
+	}
+	return UnsafeString(res)
+}
+
+func equalFoldByTable(b, s string) bool {
+	if len(b) != len(s) {
+		return false
+	}
+	for i := 0; i < len(b); i++ {
+		if toUpperTable[b[i]] != toUpperTable[s[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIIByTable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSWAR_AllBytes(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		for pos := 0; pos < 9; pos++ {
+			b := []byte("aZ@[`{09xYz\x7f\x80")
+			b = append(b[:pos], append([]byte{byte(c)}, b[pos:]...)...)
+			s := string(b)
+
+			if got, want := ToLower(s), toLowerByTable(s); got != want {
+				t.Fatalf("ToLower(%q) = %q, want %q", s, got, want)
+			}
+			if got, want := ToUpper(s), toUpperByTable(s); got != want {
+				t.Fatalf("ToUpper(%q) = %q, want %q", s, got, want)
+			}
+			if got, want := string(ToLowerBytes([]byte(s))), toLowerByTable(s); got != want {
+				t.Fatalf("ToLowerBytes(%q) = %q, want %q", s, got, want)
+			}
+			if got, want := string(ToUpperBytes([]byte(s))), toUpperByTable(s); got != want {
+				t.Fatalf("ToUpperBytes(%q) = %q, want %q", s, got, want)
+			}
+			if got, want := IsASCII(s), isASCIIByTable(s); got != want {
+				t.Fatalf("IsASCII(%q) = %v, want %v", s, got, want)
+			}
+			for d := 0; d < 256; d++ {
+				b2 := []byte(s)
+				b2[pos] = byte(d)
+				s2 := string(b2)
+				if got, want := EqualFold(s, s2), equalFoldByTable(s, s2); got != want {
+					t.Fatalf("EqualFold(%q, %q) = %v, want %v", s, s2, got, want)
+				}
+				if got, want := EqualFoldBytes([]byte(s), b2), equalFoldByTable(s, s2); got != want {
+					t.Fatalf("EqualFoldBytes(%q, %q) = %v, want %v", s, s2, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSWAR_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 1000; n++ {
+		b := make([]byte, rnd.Intn(40))
+		for i := range b {
+			if rnd.Intn(4) == 0 {
+				b[i] = byte(rnd.Intn(256))
+			} else {
+				b[i] = byte(rnd.Intn(0x80))
+			}
+		}
+		s := string(b)
+		assert.Equal(t, toLowerByTable(s), ToLower(s))
+		assert.Equal(t, toUpperByTable(s), ToUpper(s))
+		assert.Equal(t, isASCIIByTable(s), IsASCII(s))
+		assert.True(t, EqualFold(ToLower(s), ToUpper(s)))
+		assert.True(t, EqualFoldBytes([]byte(ToLower(s)), []byte(ToUpper(s))))
+	}
+}
+
+func Test_IsASCII(t *testing.T) {
+	assert.True(t, IsASCII(""))
+	assert.True(t, IsASCII("abc"))
+	assert.True(t, IsASCII(largeStr))
+	assert.False(t, IsASCII(largeStr+"тест"))
+	assert.False(t, IsASCII("\x80"+largeStr))
+	assert.False(t, IsASCII("12345678\xff"))
+}
+
+var (
+	swarLargeStr   = strings.Repeat(largeStr, 1024)
+	swarLargeUpper = strings.Repeat(upperStr, 1024)
+	swarLargeLower = strings.Repeat(lowerStr, 1024)
+)
+
+// go test -v -run=^$ -bench=Benchmark_SWAR -benchmem
+func Benchmark_SWAR_ToLower(b *testing.B) {
+	b.Run("swar", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			_ = ToLower(swarLargeStr)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			_ = toLowerByTable(swarLargeStr)
+		}
+	})
+	// without allocation
+	dst := make([]byte, len(swarLargeStr))
+	b.Run("swar_buf", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			toLowerString(dst, swarLargeStr)
+		}
+	})
+	b.Run("table_buf", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			for i := 0; i < len(swarLargeStr); i++ {
+				dst[i] = toLowerTable[swarLargeStr[i]]
+			}
+		}
+	})
+}
+
+func Benchmark_SWAR_ToUpper(b *testing.B) {
+	b.Run("swar", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			_ = ToUpper(swarLargeStr)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			_ = toUpperByTable(swarLargeStr)
+		}
+	})
+}
+
+func Benchmark_SWAR_ToLowerBytes(b *testing.B) {
+	buf := []byte(swarLargeStr)
+	b.Run("swar", func(b *testing.B) {
+		b.SetBytes(int64(len(buf)))
+		for n := 0; n < b.N; n++ {
+			_ = ToLowerBytes(buf)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		b.SetBytes(int64(len(buf)))
+		for n := 0; n < b.N; n++ {
+			for i := 0; i < len(buf); i++ {
+				buf[i] = toLowerTable[buf[i]]
+			}
+		}
+	})
+	b.Run("stdlib", func(b *testing.B) {
+		b.SetBytes(int64(len(buf)))
+		for n := 0; n < b.N; n++ {
+			_ = bytes.ToLower(buf)
+		}
+	})
+}
+
+func Benchmark_SWAR_EqualFold(b *testing.B) {
+	b.Run("swar", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeUpper)))
+		for n := 0; n < b.N; n++ {
+			_ = EqualFold(swarLargeUpper, swarLargeLower)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeUpper)))
+		for n := 0; n < b.N; n++ {
+			_ = equalFoldByTable(swarLargeUpper, swarLargeLower)
+		}
+	})
+	b.Run("stdlib", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeUpper)))
+		for n := 0; n < b.N; n++ {
+			_ = strings.EqualFold(swarLargeUpper, swarLargeLower)
+		}
+	})
+}
+
+func Benchmark_SWAR_IsASCII(b *testing.B) {
+	b.Run("swar", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			_ = IsASCII(swarLargeStr)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		b.SetBytes(int64(len(swarLargeStr)))
+		for n := 0; n < b.N; n++ {
+			_ = isASCIIByTable(swarLargeStr)
+		}
+	})
+}
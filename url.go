@@ -0,0 +1,196 @@
+package stringutils
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrURLEscape is returned when string contains invalid URL escape sequence
+var ErrURLEscape = errors.New("stringutils: invalid URL escape")
+
+const (
+	urlEscapeQuery = 1 << iota // must be escaped in query component
+	urlEscapePath              // must be escaped in path segment
+)
+
+// urlEscape[c] is a set of contexts (urlEscapeQuery, urlEscapePath), where byte c must be escaped (like in net/url)
+var urlEscape = func() (t [256]uint8) {
+	for c := 0; c < 256; c++ {
+		switch {
+		case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == '~':
+		case c == '$' || c == '&' || c == '+' || c == ':' || c == '=' || c == '@':
+			t[c] = urlEscapeQuery
+		default:
+			t[c] = urlEscapeQuery | urlEscapePath
+		}
+	}
+	return
+}()
+
+// writeURLEscaped appends s with bytes from mode escaped as %XX (and space as '+', if spacePlus).
+func (sb *Builder) writeURLEscaped(s string, mode uint8, spacePlus bool) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if urlEscape[s[i]]&mode != 0 {
+			n++
+		}
+	}
+	if n == 0 {
+		sb.WriteString(s)
+		return
+	}
+	pos := sb.extend(len(s) + 2*n)
+	b := sb.data[pos:]
+	j := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case urlEscape[c]&mode == 0:
+			b[j] = c
+			j++
+		case c == ' ' && spacePlus:
+			// shrink reserved space, only one byte used
+			b[j] = '+'
+			j++
+		default:
+			b[j] = '%'
+			b[j+1] = hexDigitsUpper[c>>4]
+			b[j+2] = hexDigitsUpper[c&0xF]
+			j += 3
+		}
+	}
+	sb.data = sb.data[:pos+j]
+}
+
+// WriteQueryEscape appends s, escaped so it can be safely placed inside a URL query, as generated by url.QueryEscape.
+func (sb *Builder) WriteQueryEscape(s string) {
+	sb.writeURLEscaped(s, urlEscapeQuery, true)
+}
+
+// WritePathEscape appends s, escaped so it can be safely placed inside a URL path segment, as generated by url.PathEscape.
+func (sb *Builder) WritePathEscape(s string) {
+	sb.writeURLEscaped(s, urlEscapePath, false)
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// QueryUnescape does the inverse transformation of QueryEscape (%XX is decoded and '+' is replaced with space).
+// Also return change flag.
+// If s needs no decoding, s is returned unchanged (without allocation).
+// Otherwise decoded string is appended to buf and returned string is a view on buf bytes
+// (valid until buf Reset, Truncate or Release, so buf can be used for decode several strings).
+func QueryUnescape(s string, buf *Builder) (string, bool, error) {
+	i := 0
+	for ; i < len(s); i++ {
+		if c := s[i]; c == '%' || c == '+' {
+			break
+		}
+	}
+	if i == len(s) {
+		return s, false, nil
+	}
+
+	start := buf.Len()
+	buf.reserve(len(s))
+	buf.data = append(buf.data, s[:i]...)
+	for ; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '%':
+			if i+2 >= len(s) {
+				buf.Truncate(start)
+				return s, false, ErrURLEscape
+			}
+			h, ok1 := unhex(s[i+1])
+			l, ok2 := unhex(s[i+2])
+			if !ok1 || !ok2 {
+				buf.Truncate(start)
+				return s, false, ErrURLEscape
+			}
+			buf.data = append(buf.data, h<<4|l)
+			i += 2
+		case '+':
+			buf.data = append(buf.data, ' ')
+		default:
+			buf.data = append(buf.data, c)
+		}
+	}
+	return UnsafeString(buf.data[start:]), true, nil
+}
+
+// QueryBuilder appends URL query pairs (k=v&k2=v2) to Builder with correct escaping, without map allocation and sorting (like url.Values.Encode).
+type QueryBuilder struct {
+	sb    *Builder
+	pairs int
+}
+
+// NewQueryBuilder return new QueryBuilder, appended to sb.
+func NewQueryBuilder(sb *Builder) *QueryBuilder {
+	return &QueryBuilder{sb: sb}
+}
+
+// Reset resets the QueryBuilder state and set Builder for append.
+func (q *QueryBuilder) Reset(sb *Builder) {
+	q.sb = sb
+	q.pairs = 0
+}
+
+// Builder return underlying Builder.
+func (q *QueryBuilder) Builder() *Builder {
+	return q.sb
+}
+
+// Len returns the number of appended pairs.
+func (q *QueryBuilder) Len() int {
+	return q.pairs
+}
+
+func (q *QueryBuilder) key(key string) {
+	if q.pairs > 0 {
+		_ = q.sb.WriteByte('&')
+	}
+	q.pairs++
+	q.sb.WriteQueryEscape(key)
+	_ = q.sb.WriteByte('=')
+}
+
+// Add appends key=value pair.
+func (q *QueryBuilder) Add(key, value string) {
+	q.key(key)
+	q.sb.WriteQueryEscape(value)
+}
+
+// AddInt appends key=value pair with integer value.
+func (q *QueryBuilder) AddInt(key string, value int64) {
+	q.key(key)
+	q.sb.WriteInt(value, 10)
+}
+
+// AddUint appends key=value pair with unsigned integer value.
+func (q *QueryBuilder) AddUint(key string, value uint64) {
+	q.key(key)
+	q.sb.WriteUint(value, 10)
+}
+
+// AddFloat appends key=value pair with floating-point value (with the minimal number of digits).
+func (q *QueryBuilder) AddFloat(key string, value float64) {
+	q.key(key)
+	var buf [64]byte
+	q.sb.WriteQueryEscape(UnsafeString(strconv.AppendFloat(buf[:0], value, 'g', -1, 64)))
+}
+
+// AddBool appends key=value pair with bool value.
+func (q *QueryBuilder) AddBool(key string, value bool) {
+	q.key(key)
+	q.sb.WriteBool(value)
+}
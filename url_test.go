@@ -0,0 +1,155 @@
+package stringutils
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var urlEscapeTests = []string{
+	"",
+	"abc",
+	"one two",
+	"10%",
+	"a/b?c=d&e+f#g",
+	"!$&'()*+,;=:@[]",
+	"-_.~",
+	"тест 世界",
+	"\x00\x7f\xff",
+	"carbon.agents.*.cpu{a,b}",
+}
+
+func TestBuilder_WriteQueryEscape(t *testing.T) {
+	for _, s := range urlEscapeTests {
+		t.Run(strconv.Quote(s), func(t *testing.T) {
+			var sb Builder
+			sb.WriteQueryEscape(s)
+			assert.Equal(t, url.QueryEscape(s), sb.String())
+		})
+	}
+}
+
+func TestBuilder_WritePathEscape(t *testing.T) {
+	for _, s := range urlEscapeTests {
+		t.Run(strconv.Quote(s), func(t *testing.T) {
+			var sb Builder
+			sb.WritePathEscape(s)
+			assert.Equal(t, url.PathEscape(s), sb.String())
+		})
+	}
+}
+
+func TestQueryUnescape(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		changed bool
+		wantErr bool
+	}{
+		{s: "", want: ""},
+		{s: "abc", want: "abc"},
+		{s: "one+two", want: "one two", changed: true},
+		{s: "a%2Fb%3fc", want: "a/b?c", changed: true},
+		{s: "%D1%82%D0%B5%D1%81%D1%82", want: "тест", changed: true},
+		{s: "10%", want: "10%", wantErr: true},
+		{s: "10%2", want: "10%2", wantErr: true},
+		{s: "%zz", want: "%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.s), func(t *testing.T) {
+			var buf Builder
+			buf.WriteString("prefix")
+			got, changed, err := QueryUnescape(tt.s, &buf)
+			if tt.wantErr {
+				assert.Equal(t, ErrURLEscape, err)
+				_, err = url.QueryUnescape(tt.s)
+				assert.Error(t, err, "net/url")
+			} else {
+				assert.NoError(t, err)
+				want, err := url.QueryUnescape(tt.s)
+				assert.NoError(t, err)
+				assert.Equal(t, want, got, "net/url")
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.changed, changed)
+			if tt.changed {
+				assert.Equal(t, "prefix"+tt.want, buf.String())
+			} else {
+				assert.Equal(t, "prefix", buf.String())
+			}
+		})
+	}
+}
+
+func TestQueryUnescape_Allocs(t *testing.T) {
+	var buf Builder
+	buf.Grow(64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		_, _, _ = QueryUnescape("target=sum%28a.b.%2A%29&from=-1h+ago", &buf)
+		_, _, _ = QueryUnescape("no_escape", &buf)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func TestQueryBuilder(t *testing.T) {
+	var sb Builder
+	sb.WriteString("http://localhost:8123/?")
+	q := NewQueryBuilder(&sb)
+	q.Add("query", "SELECT 1 FORMAT TSV")
+	q.Add("a&b", "c=d")
+	q.AddInt("max_threads", -1)
+	q.AddUint("limit", 10)
+	q.AddFloat("ratio", 1e-7)
+	q.AddBool("compress", true)
+	assert.Equal(t, 6, q.Len())
+
+	want := "http://localhost:8123/?query=SELECT+1+FORMAT+TSV&a%26b=c%3Dd&max_threads=-1&limit=10&ratio=1e-07&compress=true"
+	assert.Equal(t, want, sb.String())
+
+	u, err := url.Parse(sb.String())
+	assert.NoError(t, err)
+	v := u.Query()
+	assert.Equal(t, "SELECT 1 FORMAT TSV", v.Get("query"))
+	assert.Equal(t, "c=d", v.Get("a&b"))
+	assert.Equal(t, "1e-07", v.Get("ratio"))
+
+	sb.Reset()
+	q.Reset(&sb)
+	q.Add("k", "")
+	assert.Equal(t, "k=", sb.String())
+}
+
+func BenchmarkThis_QueryBuilder(b *testing.B) {
+	var sb Builder
+	sb.Grow(1024)
+	q := NewQueryBuilder(&sb)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sb.Reset()
+		q.Reset(&sb)
+		q.Add("target", "sumSeries(carbon.agents.*.cpu)")
+		q.Add("from", "-1h")
+		q.Add("format", "json")
+		q.AddInt("maxDataPoints", 1000)
+	}
+}
+
+func BenchmarkStd_Values_Encode(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		v := url.Values{}
+		v.Add("target", "sumSeries(carbon.agents.*.cpu)")
+		v.Add("from", "-1h")
+		v.Add("format", "json")
+		v.Add("maxDataPoints", strconv.Itoa(1000))
+		_ = v.Encode()
+	}
+}
@@ -0,0 +1,43 @@
+package stringutils
+
+import "unicode/utf8"
+
+// WriteStringValid appends s with each run of invalid UTF-8 byte sequences replaced by the replacement string (like strings.ToValidUTF8).
+func (sb *Builder) WriteStringValid(s, replacement string) {
+	start := 0
+	invalid := false // previous byte was from an invalid UTF-8 sequence
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			i++
+			invalid = false
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		if size == 1 {
+			if !invalid {
+				sb.WriteString(s[start:i])
+				sb.WriteString(replacement)
+				invalid = true
+			}
+			i++
+			start = i
+			continue
+		}
+		invalid = false
+		i += size
+	}
+	sb.WriteString(s[start:])
+}
+
+// ToValidUTF8 returns s with each run of invalid UTF-8 byte sequences replaced by the replacement string (like strings.ToValidUTF8).
+// Also return change flag. If s is valid UTF-8, it's returned unchanged (without allocation).
+func ToValidUTF8(s, replacement string) (string, bool) {
+	if utf8.ValidString(s) {
+		return s, false
+	}
+	var sb Builder
+	sb.Grow(len(s) + len(replacement))
+	sb.WriteStringValid(s, replacement)
+	return sb.String(), true
+}
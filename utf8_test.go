@@ -0,0 +1,68 @@
+package stringutils
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToValidUTF8(t *testing.T) {
+	tests := []struct {
+		s           string
+		replacement string
+		want        string
+	}{
+		{s: "", replacement: "?", want: ""},
+		{s: "hello мир", replacement: "?", want: "hello мир"},
+		{s: "a\xffb", replacement: "?", want: "a?b"},
+		{s: "a\xff\xfe\xfdb", replacement: "?", want: "a?b"},
+		{s: "\xffa\xe4\xb8", replacement: "\uFFFD", want: "\uFFFDa\uFFFD"},
+		{s: "a\xc3\x28b", replacement: "", want: "a(b"},
+		{s: "\xed\xa0\x80", replacement: "?", want: "?"},
+		{s: "世\xff界", replacement: "<bad>", want: "世<bad>界"},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.s), func(t *testing.T) {
+			got, changed := ToValidUTF8(tt.s, tt.replacement)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want != tt.s, changed)
+			assert.Equal(t, strings.ToValidUTF8(tt.s, tt.replacement), got, "strings")
+
+			var sb Builder
+			sb.WriteString("prefix")
+			sb.WriteStringValid(tt.s, tt.replacement)
+			assert.Equal(t, "prefix"+tt.want, sb.String())
+		})
+	}
+}
+
+func TestToValidUTF8_Allocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ToValidUTF8("valid string тест", "?")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkThis_ToValidUTF8(b *testing.B) {
+	s := "carbon.agents.тест.cpu"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ToValidUTF8(s, "?")
+	}
+}
+
+func BenchmarkStd_ToValidUTF8(b *testing.B) {
+	s := "carbon.agents.тест.cpu"
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = strings.ToValidUTF8(s, "?")
+	}
+}